@@ -0,0 +1,69 @@
+package prompt
+
+import (
+	"strings"
+
+	"github.com/aschey/bubbleprompt/input"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// findAutosuggestion returns the text that completes the current input, preferring the newest matching
+// history entry and falling back to the current suggestion.
+func (m Model[T]) findAutosuggestion() string {
+	if !m.autosuggestions || m.modelState != completing || !m.focus {
+		return ""
+	}
+	value := m.textInput.Value()
+	runes := m.textInput.Runes()
+	// Ghost text is only shown at the end of the input so it doesn't get mixed up with existing text
+	if len(runes) == 0 || m.textInput.CursorIndex() < len(runes) {
+		return ""
+	}
+
+	if m.history.enabled() {
+		entries := m.history.history.Entries()
+		for i := len(entries) - 1; i >= 0; i-- {
+			entry := entries[i]
+			if len(entry) > len(value) && strings.HasPrefix(entry, value) &&
+				!strings.Contains(entry[len(value):], "\n") {
+				return entry[len(value):]
+			}
+		}
+	}
+
+	suggestion := m.suggestionManager.SelectedSuggestion()
+	if suggestion == nil {
+		suggestions := m.suggestionManager.Suggestions()
+		if len(suggestions) == 0 {
+			return ""
+		}
+		suggestion = &suggestions[0]
+	}
+	token := m.textInput.CurrentToken().Value
+	suggestionText := suggestion.GetSuggestionText()
+	if len(token) == 0 || len(suggestionText) <= len(token) || !strings.HasPrefix(suggestionText, token) ||
+		!strings.HasSuffix(value, token) {
+		return ""
+	}
+	return suggestionText[len(token):]
+}
+
+func (m *Model[T]) updateAutosuggestion() {
+	m.autosuggestion = m.findAutosuggestion()
+	if autosuggester, ok := m.textInput.(input.Autosuggester); ok {
+		autosuggester.SetAutosuggestion(m.autosuggestion)
+	}
+}
+
+func (m Model[T]) shouldAcceptAutosuggestion(msg tea.KeyMsg) bool {
+	return m.autosuggestion != "" && key.Matches(msg, m.keyMap.AcceptAutosuggestion)
+}
+
+func (m *Model[T]) acceptAutosuggestion(msg tea.KeyMsg, cmds []tea.Cmd, prevRunes []rune) []tea.Cmd {
+	value := m.textInput.Value() + m.autosuggestion
+	m.textInput.SetValue(value)
+	m.textInput.SetCursor(len([]rune(value)))
+	m.autosuggestion = ""
+	return m.updateKeypress(msg, cmds, prevRunes)
+}
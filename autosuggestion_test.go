@@ -0,0 +1,125 @@
+package prompt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aschey/bubbleprompt/history"
+	"github.com/aschey/bubbleprompt/input/simpleinput"
+	"github.com/aschey/bubbleprompt/suggestion"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var (
+	keyRight = tea.KeyMsg{Type: tea.KeyRight}
+	keyEnd   = tea.KeyMsg{Type: tea.KeyEnd}
+)
+
+func TestAutosuggestion(t *testing.T) {
+	tests := []struct {
+		name        string
+		history     []string
+		suggestions []string
+		// multiline uses a multi-line input where enter inserts a newline
+		multiline bool
+		input     string
+		keys      []tea.KeyMsg
+		// autosuggestion is the ghost text shown after the keys are pressed
+		autosuggestion string
+		value          string
+	}{
+		{
+			name:           "history before suggestion",
+			history:        []string{"stash"},
+			suggestions:    []string{"status"},
+			input:          "st",
+			autosuggestion: "ash",
+			value:          "st",
+		},
+		{
+			name:           "newest history entry",
+			history:        []string{"stash pop", "stash list"},
+			input:          "st",
+			autosuggestion: "ash list",
+			value:          "st",
+		},
+		{
+			name:           "fall back to suggestion",
+			history:        []string{"commit"},
+			suggestions:    []string{"status"},
+			input:          "st",
+			autosuggestion: "atus",
+			value:          "st",
+		},
+		{name: "no match", history: []string{"commit"}, suggestions: []string{"status"}, input: "x", value: "x"},
+		{name: "empty input", history: []string{"commit"}, suggestions: []string{"status"}},
+		{
+			name:        "cursor not at end",
+			history:     []string{"stash"},
+			suggestions: []string{"status"},
+			input:       "st",
+			keys:        []tea.KeyMsg{keyLeft},
+			value:       "st",
+		},
+		{
+			name:           "skip multi-line history entries",
+			history:        []string{"stash", "stash\npop"},
+			input:          "st",
+			autosuggestion: "ash",
+			value:          "st",
+		},
+		{
+			name:           "complete last line of multi-line input",
+			history:        []string{"stash\npop"},
+			multiline:      true,
+			input:          "stash\np",
+			autosuggestion: "op",
+			value:          "stash\np",
+		},
+		{name: "accept with right", history: []string{"stash"}, input: "st", keys: []tea.KeyMsg{keyRight}, value: "stash"},
+		{name: "accept with end", history: []string{"stash"}, input: "st", keys: []tea.KeyMsg{keyEnd}, value: "stash"},
+		{
+			name:        "accept suggestion",
+			suggestions: []string{"status"},
+			input:       "st",
+			keys:        []tea.KeyMsg{keyRight},
+			value:       "status",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler := &testHandler{}
+			for _, text := range test.suggestions {
+				handler.suggestions = append(handler.suggestions, suggestion.Suggestion[any]{Text: text})
+			}
+			memoryHistory := history.NewMemoryHistory()
+			for _, entry := range test.history {
+				if err := memoryHistory.Add(entry); err != nil {
+					t.Fatal(err)
+				}
+			}
+			opts := []Option[any]{WithHistory[any](memoryHistory), WithAutosuggestions[any]()}
+			var p *testPrompt
+			if test.multiline {
+				textInput := simpleinput.New(simpleinput.WithMultiline[any]())
+				p = newTestPromptWithInput(t, multilineHandler{handler}, handler, textInput, opts...)
+			} else {
+				p = newTestPrompt(t, handler, opts...)
+			}
+			lines := strings.Split(test.input, "\n")
+			for _, line := range lines[:len(lines)-1] {
+				p.submit(line)
+			}
+			p.typeText(lines[len(lines)-1])
+			for _, key := range test.keys {
+				p.send(key)
+			}
+			if p.model.autosuggestion != test.autosuggestion {
+				t.Errorf("autosuggestion = %q, want %q", p.model.autosuggestion, test.autosuggestion)
+			}
+			if p.value() != test.value {
+				t.Errorf("value = %q, want %q", p.value(), test.value)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package cobraprompt_test
+
+import (
+	"fmt"
+
+	"github.com/aschey/bubbleprompt/cobraprompt"
+	"github.com/aschey/bubbleprompt/input/commandinput"
+	"github.com/spf13/cobra"
+)
+
+func ExampleSuggestions() {
+	root := &cobra.Command{Use: "app"}
+	get := &cobra.Command{
+		Use:   "get <name>",
+		Short: "get a value",
+		Run:   func(cmd *cobra.Command, args []string) {},
+	}
+	get.Flags().IntP("count", "c", 1, "number of values")
+	get.Flags().Bool("verbose", false, "show extra info")
+	root.AddCommand(get)
+
+	textInput := commandinput.New[*cobra.Command]()
+	suggestions := cobraprompt.Suggestions(textInput, root)
+	fmt.Println(suggestions[0].Text, suggestions[0].Metadata.PositionalArgs[0].Placeholder())
+
+	for _, flag := range cobraprompt.FlagInputs(textInput, get) {
+		fmt.Println(flag.ShortFlag(), flag.LongFlag(), flag.ArgPlaceholder.Text())
+	}
+	// Output:
+	// get <name>
+	// -c --count <int>
+	//  --verbose
+}
@@ -0,0 +1,202 @@
+// Package cobraprompt provides an input handler that completes and executes the commands in a cobra command tree.
+package cobraprompt
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"sync"
+
+	prompt "github.com/aschey/bubbleprompt"
+	"github.com/aschey/bubbleprompt/completer"
+	"github.com/aschey/bubbleprompt/executor"
+	"github.com/aschey/bubbleprompt/input/commandinput"
+	"github.com/aschey/bubbleprompt/suggestion"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Model is a [prompt.InputHandler] for a cobra command tree.
+// The subcommands of the root command are suggested as the top-level commands
+// and flags are suggested once a command has been entered.
+type Model struct {
+	root        *cobra.Command
+	textInput   *commandinput.Model[*cobra.Command]
+	suggestions []suggestion.Suggestion[Metadata]
+	filterer    completer.Filterer[Metadata]
+	// executeLock ensures commands run one at a time since they share the root command's flags and output
+	executeLock *sync.Mutex
+}
+
+// New creates a new model from the root command.
+// Errors and usage text are silenced on the root command
+// since errors are displayed by the prompt instead.
+func New(
+	root *cobra.Command,
+	textInput *commandinput.Model[*cobra.Command],
+	options ...Option,
+) Model {
+	root.InitDefaultHelpCmd()
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+
+	model := Model{
+		root:        root,
+		textInput:   textInput,
+		suggestions: Suggestions(textInput, root),
+		filterer:    completer.NewPrefixFilter[Metadata](),
+		executeLock: &sync.Mutex{},
+	}
+	for _, option := range options {
+		option(&model)
+	}
+	return model
+}
+
+// Suggestions returns the suggestions generated from the command tree.
+func (m Model) Suggestions() []suggestion.Suggestion[Metadata] {
+	return m.suggestions
+}
+
+// Complete is part of the [prompt.InputHandler] interface. It should not be invoked by users of this library.
+func (m Model) Complete(promptModel prompt.Model[Metadata]) ([]suggestion.Suggestion[Metadata], error) {
+	current := m.textInput.CurrentTokenBeforeCursor()
+	cursor := m.textInput.CursorIndex()
+	suggestions := m.suggestions
+	var command *suggestion.Suggestion[Metadata]
+	argCount := 0
+	hasFlags := false
+
+	for _, token := range m.textInput.Tokens() {
+		if cursor <= token.End() {
+			break
+		}
+		if strings.HasPrefix(token.Value, "-") {
+			hasFlags = true
+			break
+		}
+		if argCount == 0 {
+			if child := findSuggestion(suggestions, token.Value); child != nil {
+				command = child
+				suggestions = child.Metadata.Children
+				continue
+			}
+		}
+		argCount++
+	}
+
+	currentIsFlag := strings.HasPrefix(current.Value, "-")
+	if !hasFlags && !currentIsFlag && argCount == 0 && len(suggestions) > 0 {
+		return m.filterer.Filter(current.Value, suggestions), nil
+	}
+	if command == nil {
+		return nil, nil
+	}
+	// Only suggest flags once all of the positional args have been supplied unless the user is already typing a flag
+	if hasFlags || currentIsFlag || argCount >= len(command.Metadata.PositionalArgs) {
+		flags := FlagInputs(m.textInput, command.Metadata.Extra)
+		return m.textInput.FlagSuggestions(current.Value, flags, nil), nil
+	}
+	return nil, nil
+}
+
+func findSuggestion(suggestions []suggestion.Suggestion[Metadata], text string) *suggestion.Suggestion[Metadata] {
+	for i, suggestion := range suggestions {
+		if suggestion.Text == text {
+			return &suggestions[i]
+		}
+	}
+	return nil
+}
+
+// Execute is part of the [prompt.InputHandler] interface. It should not be invoked by users of this library.
+// The parsed input is passed to the root command and anything written to the command's output is displayed.
+// Commands should write to [cobra.Command.OutOrStdout] rather than directly to stdout
+// so that their output can be captured.
+// Commands run one at a time, so a command that's started while another one is running in the background
+// waits for it to finish.
+// Pipelines and redirects aren't supported and return an error.
+func (m Model) Execute(input string, promptModel *prompt.Model[Metadata]) (tea.Model, error) {
+	args, err := m.args()
+	if err != nil {
+		return nil, err
+	}
+
+	return executor.NewAsyncStringModel(func() (string, error) {
+		m.executeLock.Lock()
+		defer m.executeLock.Unlock()
+		defer resetFlags(m.root)
+
+		output := &bytes.Buffer{}
+		m.root.SetOut(output)
+		m.root.SetErr(output)
+		m.root.SetArgs(args)
+		err := m.root.Execute()
+		return output.String(), err
+	}), nil
+}
+
+// args converts the parsed input into the arguments expected by cobra.
+func (m Model) args() ([]string, error) {
+	parsed := m.textInput.ParsedValue()
+	if pipeline := m.textInput.Pipeline(); len(pipeline.Segments) > 0 {
+		if len(pipeline.Segments) > 1 || len(pipeline.Segments[0].Statement.Redirects) > 0 {
+			return nil, errors.New("pipelines aren't supported")
+		}
+		parsed = pipeline.Segments[0].Statement
+	}
+	runes := m.textInput.Runes()
+	args := []string{}
+	if parsed.Command.Value != "" {
+		args = append(args, parsed.Command.Unquote())
+	}
+	for _, arg := range parsed.Args {
+		args = append(args, arg.Unquote())
+	}
+	for _, flag := range parsed.Flags {
+		nameEnd := flag.Name.End()
+		if flag.Value != nil && nameEnd < len(runes) && runes[nameEnd] == '=' {
+			args = append(args, flag.Name.Value+"="+flag.Value.Unquote())
+			continue
+		}
+		args = append(args, flag.Name.Value)
+		if flag.Value != nil {
+			args = append(args, flag.Value.Unquote())
+		}
+	}
+	return args, nil
+}
+
+// resetFlags restores the default flag values since cobra doesn't reset them between executions.
+func resetFlags(command *cobra.Command) {
+	command.Flags().VisitAll(resetFlag)
+	command.PersistentFlags().VisitAll(resetFlag)
+	for _, child := range command.Commands() {
+		resetFlags(child)
+	}
+}
+
+func resetFlag(flag *pflag.Flag) {
+	if sliceValue, ok := flag.Value.(pflag.SliceValue); ok {
+		defaultValue := strings.Trim(flag.DefValue, "[]")
+		values := []string{}
+		if defaultValue != "" {
+			values = strings.Split(defaultValue, ",")
+		}
+		_ = sliceValue.Replace(values)
+	} else {
+		_ = flag.Value.Set(flag.DefValue)
+	}
+	flag.Changed = false
+}
+
+// Init is part of the [prompt.InputHandler] interface. It should not be invoked by users of this library.
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update is part of the [prompt.InputHandler] interface. It should not be invoked by users of this library.
+func (m Model) Update(msg tea.Msg) (prompt.InputHandler[Metadata], tea.Cmd) {
+	return m, nil
+}
@@ -0,0 +1,71 @@
+package cobraprompt_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	prompt "github.com/aschey/bubbleprompt"
+	"github.com/aschey/bubbleprompt/cobraprompt"
+	"github.com/aschey/bubbleprompt/input/commandinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+)
+
+// runExecutor runs the executor's work without the spinner.
+func runExecutor(model tea.Model) tea.Msg {
+	batch := model.Init()().(tea.BatchMsg)
+	return batch[len(batch)-1]()
+}
+
+func TestExecuteConcurrently(t *testing.T) {
+	root := &cobra.Command{Use: "app"}
+	greet := &cobra.Command{
+		Use: "greet",
+		Run: func(cmd *cobra.Command, args []string) {
+			name, _ := cmd.Flags().GetString("name")
+			// Give the other command a chance to change the flags
+			time.Sleep(20 * time.Millisecond)
+			cmd.Println("hello " + name)
+		},
+	}
+	greet.Flags().String("name", "world", "who to greet")
+	root.AddCommand(greet)
+
+	textInput := commandinput.New[*cobra.Command]()
+	model := cobraprompt.New(root, textInput)
+	promptModel := prompt.New[cobraprompt.Metadata](model, textInput)
+
+	inputs := map[string]string{
+		"greet --name bob": "hello bob\n",
+		"greet":            "hello world\n",
+	}
+	executors := map[string]tea.Model{}
+	for input := range inputs {
+		textInput.SetValue(input)
+		executor, err := model.Execute(input, &promptModel)
+		if err != nil {
+			t.Fatal(err)
+		}
+		executors[input] = executor
+	}
+
+	wg := sync.WaitGroup{}
+	outputs := sync.Map{}
+	for input, executor := range executors {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			outputs.Store(input, runExecutor(executor))
+		}()
+	}
+	wg.Wait()
+
+	for input, want := range inputs {
+		output, _ := outputs.Load(input)
+		executor, _ := executors[input].Update(output)
+		if executor.View() != want {
+			t.Errorf("%q output = %q, want %q", input, executor.View(), want)
+		}
+	}
+}
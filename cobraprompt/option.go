@@ -0,0 +1,13 @@
+package cobraprompt
+
+import "github.com/aschey/bubbleprompt/completer"
+
+type Option func(model *Model)
+
+// WithFilterer sets the filterer used to match subcommands against the current input.
+// Defaults to [completer.PrefixFilter].
+func WithFilterer(filterer completer.Filterer[Metadata]) Option {
+	return func(model *Model) {
+		model.filterer = filterer
+	}
+}
@@ -0,0 +1,188 @@
+package cobraprompt
+
+import (
+	"strings"
+
+	"github.com/aschey/bubbleprompt/input/commandinput"
+	"github.com/aschey/bubbleprompt/suggestion"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Metadata is the suggestion metadata generated from a cobra command.
+// The command is stored in the Extra field.
+type Metadata = commandinput.CommandMetadata[*cobra.Command]
+
+// Suggestions generates a suggestion for each available subcommand of the supplied command.
+// Subcommands are included recursively as children of each suggestion
+// and positional args are generated from the command's Use line.
+// Hidden and deprecated commands are skipped.
+func Suggestions(
+	textInput *commandinput.Model[*cobra.Command],
+	command *cobra.Command,
+) []suggestion.Suggestion[Metadata] {
+	suggestions := []suggestion.Suggestion[Metadata]{}
+	for _, child := range command.Commands() {
+		if !child.IsAvailableCommand() && child.Name() != "help" {
+			continue
+		}
+		suggestions = append(suggestions, suggestion.Suggestion[Metadata]{
+			Text:        child.Name(),
+			Description: child.Short,
+			Metadata:    commandMetadata(textInput, child),
+		})
+	}
+	return suggestions
+}
+
+func commandMetadata(textInput *commandinput.Model[*cobra.Command], command *cobra.Command) Metadata {
+	children := Suggestions(textInput, command)
+	usage := usageArgs(command)
+	positionalArgs, err := textInput.ParseUsage(usage)
+	if err != nil {
+		positionalArgs = nil
+	}
+	if len(positionalArgs) == 0 && len(children) > 0 {
+		positionalArgs = textInput.NewPositionalArgs("<command>")
+	}
+	if values := argValues(textInput, command); values != nil {
+		if len(positionalArgs) == 0 {
+			positionalArgs = textInput.NewPositionalArgs("[arg]")
+		}
+		for i := range positionalArgs {
+			positionalArgs[i].Values = values
+		}
+	}
+	if len(children) == 0 {
+		children = nil
+	}
+
+	return Metadata{
+		PositionalArgs:      positionalArgs,
+		ShowFlagPlaceholder: command.HasAvailableFlags() || command.HasAvailableInheritedFlags(),
+		Variadic:            strings.Contains(usage, "..."),
+		Children:            children,
+		Extra:               command,
+	}
+}
+
+// usageArgs returns the portion of the Use line that describes the positional args.
+func usageArgs(command *cobra.Command) string {
+	fields := strings.Fields(command.Use)
+	args := []string{}
+	if len(fields) > 1 {
+		for _, field := range fields[1:] {
+			if field != "[flags]" {
+				args = append(args, field)
+			}
+		}
+	}
+	return strings.Join(args, " ")
+}
+
+// FlagInputs generates a [commandinput.FlagInput] for each available flag of the supplied command,
+// including flags inherited from its parents.
+// Flags that don't require an argument, such as boolean flags, don't get an argument placeholder.
+func FlagInputs(
+	textInput *commandinput.Model[*cobra.Command],
+	command *cobra.Command,
+) []commandinput.FlagInput {
+	flagInputs := []commandinput.FlagInput{}
+	addFlag := func(flag *pflag.Flag) {
+		if flag.Hidden || flag.Deprecated != "" {
+			return
+		}
+		flagInput := commandinput.FlagInput{
+			Short:       flag.Shorthand,
+			Long:        flag.Name,
+			Description: flag.Usage,
+			ValueType:   flagValueType(flag),
+		}
+		if flag.Shorthand != "" && flag.ShorthandDeprecated != "" {
+			flagInput.Short = ""
+		}
+		if flag.NoOptDefVal == "" {
+			flagInput.ArgPlaceholder = textInput.NewFlagPlaceholder("<" + flag.Value.Type() + ">")
+		}
+		if _, ok := flag.Annotations[cobra.BashCompFilenameExt]; ok {
+			flagInput.ValueType = commandinput.FlagValuePath
+		}
+		if completionFunc, ok := command.GetFlagCompletionFunc(flag.Name); ok {
+			flagInput.Values = completionValues(textInput, command, completionFunc)
+		}
+		flagInputs = append(flagInputs, flagInput)
+	}
+	command.LocalFlags().VisitAll(addFlag)
+	command.InheritedFlags().VisitAll(addFlag)
+
+	return flagInputs
+}
+
+func flagValueType(flag *pflag.Flag) commandinput.FlagValueType {
+	// Strip the bit size from numeric types
+	switch strings.TrimRight(flag.Value.Type(), "0123456789") {
+	case "bool":
+		return commandinput.FlagValueBool
+	case "int", "uint", "count":
+		return commandinput.FlagValueInt
+	case "float":
+		return commandinput.FlagValueFloat
+	case "duration":
+		return commandinput.FlagValueDuration
+	case "string":
+		return commandinput.FlagValueString
+	}
+	return commandinput.FlagValueUntyped
+}
+
+// argValues creates a value source from the command's ValidArgs or ValidArgsFunction.
+func argValues(textInput *commandinput.Model[*cobra.Command], command *cobra.Command) commandinput.ValueSource {
+	if len(command.ValidArgs) > 0 {
+		return commandinput.ValueFunc(func(prefix string) []suggestion.Suggestion[any] {
+			return filterValues(command.ValidArgs, prefix)
+		})
+	}
+	if command.ValidArgsFunction != nil {
+		return completionValues(textInput, command, command.ValidArgsFunction)
+	}
+	return nil
+}
+
+func completionValues(
+	textInput *commandinput.Model[*cobra.Command],
+	command *cobra.Command,
+	completionFunc func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective),
+) commandinput.ValueSource {
+	return commandinput.ValueFunc(func(prefix string) []suggestion.Suggestion[any] {
+		values, _ := completionFunc(command, commandArgs(textInput, command), prefix)
+		return filterValues(values, prefix)
+	})
+}
+
+// commandArgs returns the completed positional args that come after the command path.
+func commandArgs(textInput *commandinput.Model[*cobra.Command], command *cobra.Command) []string {
+	args := textInput.CompletedArgsBeforeCursor()
+	// The root command isn't part of the input and the first subcommand is parsed as the command rather than an arg
+	subcommandDepth := 0
+	for parent := command.Parent(); parent != nil && parent.HasParent(); parent = parent.Parent() {
+		subcommandDepth++
+	}
+	if subcommandDepth >= len(args) {
+		return []string{}
+	}
+	return args[subcommandDepth:]
+}
+
+// filterValues converts cobra completions into suggestions.
+// Cobra completions can contain a description after a tab character.
+func filterValues(values []string, prefix string) []suggestion.Suggestion[any] {
+	prefix = strings.TrimLeft(prefix, `"'`)
+	suggestions := []suggestion.Suggestion[any]{}
+	for _, value := range values {
+		text, description, _ := strings.Cut(value, "\t")
+		if strings.HasPrefix(text, prefix) {
+			suggestions = append(suggestions, suggestion.Suggestion[any]{Text: text, Description: description})
+		}
+	}
+	return suggestions
+}
@@ -1,6 +1,7 @@
 package completer
 
 import (
+	"slices"
 	"strings"
 
 	"github.com/aschey/bubbleprompt/suggestion"
@@ -29,8 +30,13 @@ func (f PrefixFilter[T]) Filter(
 	filtered := []suggestion.Suggestion[T]{}
 	for _, s := range suggestions {
 		suggestionText := strings.ToLower(s.GetSuggestionText())
-		if strings.HasPrefix(suggestionText, cleanedSearch) ||
-			strings.HasPrefix(s.Text, cleanedSearch) {
+		switch {
+		case strings.HasPrefix(suggestionText, cleanedSearch):
+			s.MatchedIndexes = prefixIndexes(cleanedSearch)
+			filtered = append(filtered, s)
+		case strings.HasPrefix(s.Text, cleanedSearch):
+			// The prefix isn't part of the text that's shown so there's nothing to highlight
+			s.MatchedIndexes = nil
 			filtered = append(filtered, s)
 		}
 	}
@@ -38,6 +44,15 @@ func (f PrefixFilter[T]) Filter(
 	return filtered
 }
 
+// prefixIndexes returns the index of each rune in the prefix.
+func prefixIndexes(prefix string) []int {
+	indexes := []int{}
+	for i := range []rune(prefix) {
+		indexes = append(indexes, i)
+	}
+	return indexes
+}
+
 type FuzzyFilter[T any] struct{}
 
 func NewFuzzyFilter[T any]() FuzzyFilter[T] {
@@ -59,14 +74,35 @@ func (f FuzzyFilter[T]) Filter(
 	suggestions []suggestion.Suggestion[T],
 ) []suggestion.Suggestion[T] {
 	if search == "" {
-		return suggestions
+		// Nothing matched, so clear the highlighting from the previous search
+		filtered := []suggestion.Suggestion[T]{}
+		for _, s := range suggestions {
+			s.MatchedIndexes = nil
+			filtered = append(filtered, s)
+		}
+		return filtered
 	}
 
 	matches := fuzzy.FindFrom(search, suggestionSource[T](suggestions))
 	filtered := []suggestion.Suggestion[T]{}
 	for _, match := range matches {
-		filtered = append(filtered, suggestions[match.Index])
+		matched := suggestions[match.Index]
+		matched.MatchedIndexes = runeIndexes(match.Str, match.MatchedIndexes)
+		filtered = append(filtered, matched)
 	}
 
 	return filtered
 }
+
+// runeIndexes converts the byte indexes returned by the fuzzy matcher to rune indexes.
+func runeIndexes(text string, byteIndexes []int) []int {
+	indexes := []int{}
+	runeIndex := 0
+	for byteIndex := range text {
+		if slices.Contains(byteIndexes, byteIndex) {
+			indexes = append(indexes, runeIndex)
+		}
+		runeIndex++
+	}
+	return indexes
+}
@@ -0,0 +1,145 @@
+package completer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aschey/bubbleprompt/suggestion"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
+)
+
+func TestRuneIndexes(t *testing.T) {
+	tests := []struct {
+		name        string
+		text        string
+		byteIndexes []int
+		expected    []int
+	}{
+		{name: "ascii", text: "hello", byteIndexes: []int{0, 2, 4}, expected: []int{0, 2, 4}},
+		{name: "two byte runes", text: "héllo", byteIndexes: []int{1, 3, 4}, expected: []int{1, 2, 3}},
+		{name: "wide runes", text: "日本語", byteIndexes: []int{3, 6}, expected: []int{1, 2}},
+		{name: "no matches", text: "héllo", byteIndexes: nil, expected: []int{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := runeIndexes(test.text, test.byteIndexes); !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestPrefixIndexes(t *testing.T) {
+	tests := []struct {
+		prefix   string
+		expected []int
+	}{
+		{prefix: "", expected: []int{}},
+		{prefix: "ab", expected: []int{0, 1}},
+		{prefix: "日本", expected: []int{0, 1}},
+		{prefix: "ca fé", expected: []int{0, 1, 2, 3, 4}},
+	}
+	for _, test := range tests {
+		t.Run(test.prefix, func(t *testing.T) {
+			if got := prefixIndexes(test.prefix); !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestPrefixFilter(t *testing.T) {
+	suggestions := []suggestion.Suggestion[any]{
+		{Text: "café"},
+		{Text: "cat"},
+		{Text: "--color", SuggestionText: "color"},
+		{Text: "dog"},
+	}
+	filtered := NewPrefixFilter[any]().Filter("CA", suggestions)
+	expected := map[string][]int{"café": {0, 1}, "cat": {0, 1}}
+	if len(filtered) != len(expected) {
+		t.Fatalf("expected %d suggestions, got %v", len(expected), filtered)
+	}
+	for _, s := range filtered {
+		if !reflect.DeepEqual(s.MatchedIndexes, expected[s.Text]) {
+			t.Errorf("%s: expected %v, got %v", s.Text, expected[s.Text], s.MatchedIndexes)
+		}
+	}
+
+	// The matched prefix isn't part of the suggestion text
+	filtered = NewPrefixFilter[any]().Filter("--c", suggestions)
+	if len(filtered) != 1 || filtered[0].Text != "--color" || filtered[0].MatchedIndexes != nil {
+		t.Errorf("expected --color without matches, got %v", filtered)
+	}
+}
+
+func TestFuzzyFilter(t *testing.T) {
+	suggestions := []suggestion.Suggestion[any]{{Text: "café"}, {Text: "tea"}}
+	filtered := NewFuzzyFilter[any]().Filter("fé", suggestions)
+	if len(filtered) != 1 || filtered[0].Text != "café" {
+		t.Fatalf("expected café, got %v", filtered)
+	}
+	if expected := []int{2, 3}; !reflect.DeepEqual(filtered[0].MatchedIndexes, expected) {
+		t.Errorf("expected %v, got %v", expected, filtered[0].MatchedIndexes)
+	}
+}
+
+func TestFuzzyFilterEmptySearch(t *testing.T) {
+	// Suggestions that are reused between searches still have the indexes from the previous search
+	suggestions := []suggestion.Suggestion[any]{{Text: "café", MatchedIndexes: []int{2, 3}}, {Text: "tea"}}
+	filtered := NewFuzzyFilter[any]().Filter("", suggestions)
+	if len(filtered) != len(suggestions) {
+		t.Fatalf("expected every suggestion, got %v", filtered)
+	}
+	for _, s := range filtered {
+		if s.MatchedIndexes != nil {
+			t.Errorf("%s: expected no matches, got %v", s.Text, s.MatchedIndexes)
+		}
+	}
+	if suggestions[0].MatchedIndexes == nil {
+		t.Error("expected the original suggestions to be unchanged")
+	}
+}
+
+func TestFilterHighlight(t *testing.T) {
+	prevProfile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.ANSI)
+	t.Cleanup(func() { lipgloss.SetColorProfile(prevProfile) })
+
+	matchStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("5"))
+	text := suggestion.SuggestionText{}
+	tests := []struct {
+		name        string
+		filterer    Filterer[any]
+		search      string
+		suggestion  string
+		highlighted string
+	}{
+		{name: "prefix", filterer: NewPrefixFilter[any](), search: "日本", suggestion: "日本語", highlighted: "日本"},
+		{name: "fuzzy", filterer: NewFuzzyFilter[any](), search: "fé", suggestion: "café", highlighted: "fé"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filtered := test.filterer.Filter(test.search, []suggestion.Suggestion[any]{{Text: test.suggestion}})
+			if len(filtered) != 1 {
+				t.Fatalf("expected one suggestion, got %v", filtered)
+			}
+			rendered := text.FormatMatches(
+				test.suggestion,
+				text.Width(test.suggestion),
+				false,
+				filtered[0].MatchedIndexes,
+				matchStyle,
+			)
+			if !strings.Contains(rendered, matchStyle.Render(test.highlighted)) {
+				t.Errorf("expected %q to be highlighted in %q", test.highlighted, rendered)
+			}
+			if got := strings.TrimRight(ansi.Strip(rendered), " "); got != test.suggestion {
+				t.Errorf("expected %q, got %q", test.suggestion, got)
+			}
+		})
+	}
+}
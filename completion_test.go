@@ -0,0 +1,158 @@
+package prompt
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aschey/bubbleprompt/input/simpleinput"
+	"github.com/aschey/bubbleprompt/suggestion"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// blockingCompleter waits until it's released or cancelled before returning its suggestions.
+type blockingCompleter struct {
+	*testHandler
+	started chan context.Context
+	release chan struct{}
+}
+
+func (h blockingCompleter) Update(msg tea.Msg) (InputHandler[any], tea.Cmd) {
+	_, cmd := h.testHandler.Update(msg)
+	return h, cmd
+}
+
+func (h blockingCompleter) CompleteContext(
+	ctx context.Context,
+	prompt Model[any],
+) ([]suggestion.Suggestion[any], error) {
+	h.started <- ctx
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-h.release:
+		return h.Complete(prompt)
+	}
+}
+
+// countingCompleter counts the number of times suggestions are generated.
+type countingCompleter struct {
+	*testHandler
+	calls *atomic.Int32
+}
+
+func (h countingCompleter) Update(msg tea.Msg) (InputHandler[any], tea.Cmd) {
+	_, cmd := h.testHandler.Update(msg)
+	return h, cmd
+}
+
+func (h countingCompleter) Complete(prompt Model[any]) ([]suggestion.Suggestion[any], error) {
+	h.calls.Add(1)
+	return h.testHandler.Complete(prompt)
+}
+
+// startCmd runs the command in the background and sends every message that it produces once it's done.
+func startCmd(cmd tea.Cmd) <-chan []tea.Msg {
+	result := make(chan []tea.Msg, 1)
+	go func() {
+		result <- collectMsgs(cmd)
+	}()
+	return result
+}
+
+func collectMsgs(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	switch msg := cmd().(type) {
+	case nil:
+		return nil
+	case tea.BatchMsg:
+		msgs := []tea.Msg{}
+		for _, cmd := range msg {
+			msgs = append(msgs, collectMsgs(cmd)...)
+		}
+		return msgs
+	default:
+		return []tea.Msg{msg}
+	}
+}
+
+func suggestionMsgs(msgs []tea.Msg) []suggestion.SuggestionMsg[any] {
+	suggestionMsgs := []suggestion.SuggestionMsg[any]{}
+	for _, msg := range msgs {
+		if msg, ok := msg.(suggestion.SuggestionMsg[any]); ok {
+			suggestionMsgs = append(suggestionMsgs, msg)
+		}
+	}
+	return suggestionMsgs
+}
+
+func receive[T any](t *testing.T, ch <-chan T) T {
+	t.Helper()
+	select {
+	case value := <-ch:
+		return value
+	case <-time.After(time.Second):
+		t.Fatal("timed out")
+		var value T
+		return value
+	}
+}
+
+func TestCompletionCancelsPreviousContext(t *testing.T) {
+	handler := blockingCompleter{
+		testHandler: &testHandler{suggestions: []suggestion.Suggestion[any]{{Text: "s1"}}},
+		started:     make(chan context.Context, 1),
+		release:     make(chan struct{}),
+	}
+	var model tea.Model = New[any](handler, simpleinput.New[any]())
+
+	model, cmd := model.Update(suggestion.CompleteMsg{})
+	first := startCmd(cmd)
+	firstCtx := receive(t, handler.started)
+
+	_, cmd = model.Update(suggestion.CompleteMsg{})
+	if firstCtx.Err() != context.Canceled {
+		t.Errorf("first context error = %v, want %v", firstCtx.Err(), context.Canceled)
+	}
+	second := startCmd(cmd)
+	secondCtx := receive(t, handler.started)
+	close(handler.release)
+
+	if msgs := suggestionMsgs(receive(t, first)); len(msgs) != 0 {
+		t.Errorf("cancelled completion sent %+v", msgs)
+	}
+	msgs := suggestionMsgs(receive(t, second))
+	if len(msgs) != 1 || msgs[0].SequenceNumber != 1 || len(msgs[0].Suggestions) != 1 {
+		t.Fatalf("suggestion messages = %+v, want one message with sequence number 1", msgs)
+	}
+	if secondCtx.Err() != nil {
+		t.Errorf("second context error = %v, want nil", secondCtx.Err())
+	}
+}
+
+func TestCompletionDebounce(t *testing.T) {
+	calls := &atomic.Int32{}
+	handler := countingCompleter{testHandler: &testHandler{}, calls: calls}
+	var model tea.Model = New[any](handler, simpleinput.New[any](), WithCompletionDebounce[any](50*time.Millisecond))
+
+	results := []<-chan []tea.Msg{}
+	for range 3 {
+		var cmd tea.Cmd
+		model, cmd = model.Update(suggestion.CompleteMsg{})
+		results = append(results, startCmd(cmd))
+	}
+
+	msgs := []suggestion.SuggestionMsg[any]{}
+	for _, result := range results {
+		msgs = append(msgs, suggestionMsgs(receive(t, result))...)
+	}
+	if len(msgs) != 1 || msgs[0].SequenceNumber != 2 {
+		t.Errorf("suggestion messages = %+v, want one message with sequence number 2", msgs)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("completions = %d, want 1", calls.Load())
+	}
+}
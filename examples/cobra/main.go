@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	prompt "github.com/aschey/bubbleprompt"
+	"github.com/aschey/bubbleprompt/cobraprompt"
+	"github.com/aschey/bubbleprompt/input/commandinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+)
+
+func newRootCmd() *cobra.Command {
+	secret := "hunter2"
+
+	root := &cobra.Command{
+		Use:   "app",
+		Short: "an example cobra app",
+	}
+	root.PersistentFlags().BoolP("verbose", "v", false, "show extra output")
+
+	get := &cobra.Command{
+		Use:   "get",
+		Short: "retrieve things",
+	}
+	weather := &cobra.Command{
+		Use:   "weather",
+		Short: "get the weather",
+		Run: func(cmd *cobra.Command, args []string) {
+			days, _ := cmd.Flags().GetInt("days")
+			delay, _ := cmd.Flags().GetDuration("delay")
+			time.Sleep(delay)
+			fmt.Fprintf(cmd.OutOrStdout(), "weather for the next %d day(s) is: cloudy with a chance of meatballs\n", days)
+		},
+	}
+	weather.Flags().IntP("days", "d", 1, "forecast days")
+	weather.Flags().Duration("delay", 0, "time to wait before showing the forecast")
+
+	getSecret := &cobra.Command{
+		Use:   "secret",
+		Short: "get the secret",
+		Run: func(cmd *cobra.Command, args []string) {
+			if verbose, _ := cmd.Flags().GetBool("verbose"); verbose {
+				fmt.Fprintln(cmd.OutOrStdout(), "retrieving the secret...")
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), "the secret is: "+secret)
+		},
+	}
+	get.AddCommand(weather, getSecret)
+
+	set := &cobra.Command{
+		Use:   "set",
+		Short: "update things",
+	}
+	setSecret := &cobra.Command{
+		Use:   "secret <secret value>",
+		Short: "update the secret",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			secret = args[0]
+			fmt.Fprintln(cmd.OutOrStdout(), "secret updated")
+		},
+	}
+	set.AddCommand(setSecret)
+
+	echo := &cobra.Command{
+		Use:   "echo [words...]",
+		Short: "print the arguments",
+		Run: func(cmd *cobra.Command, args []string) {
+			separator, _ := cmd.Flags().GetString("separator")
+			fmt.Fprintln(cmd.OutOrStdout(), strings.Join(args, separator))
+		},
+	}
+	echo.Flags().StringP("separator", "s", " ", "text to insert between the arguments")
+
+	root.AddCommand(get, set, echo)
+	return root
+}
+
+func main() {
+	textInput := commandinput.New[*cobra.Command]()
+	model := cobraprompt.New(newRootCmd(), textInput)
+	promptModel := prompt.New[cobraprompt.Metadata](model, textInput)
+
+	if _, err := tea.NewProgram(promptModel, tea.WithFilter(prompt.MsgFilter)).Run(); err != nil {
+		fmt.Printf("Could not start program\n%v\n", err)
+		os.Exit(1)
+	}
+}
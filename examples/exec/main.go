@@ -4,10 +4,14 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strconv"
 	"strings"
 
 	prompt "github.com/aschey/bubbleprompt"
 	"github.com/aschey/bubbleprompt/completer"
+	"github.com/aschey/bubbleprompt/executor"
+	"github.com/aschey/bubbleprompt/history"
 	"github.com/aschey/bubbleprompt/input/commandinput"
 	"github.com/aschey/bubbleprompt/suggestion"
 	tea "github.com/charmbracelet/bubbletea"
@@ -51,21 +55,20 @@ func (m cmdModel) View() string {
 
 type processFinishedMsg struct{ err error }
 
+var fullscreenCommands = map[string]bool{"vim": true, "emacs": true, "nano": true, "top": true, "htop": true}
+
+var interactiveCommands = map[string]bool{"python3": true, "ssh": true, "bc": true, "sudo": true, "passwd": true}
+
 func (m model) Complete(
 	promptModel prompt.Model[cmdMetadata],
 ) ([]suggestion.Suggestion[cmdMetadata], error) {
+	// Filenames are completed by the positional arg's value source
 	if !m.textInput.CommandCompleted() {
 		return m.filterer.Filter(
 			m.textInput.CurrentTokenBeforeCursor().Value,
 			m.suggestions,
 		), nil
 	}
-
-	parsed := m.textInput.ParsedValue()
-	if len(parsed.Args) > 0 && len(m.textInput.CompletedArgsBeforeCursor()) == 0 {
-		pathCompleter := completer.PathCompleter[cmdMetadata]{}
-		return pathCompleter.Complete(m.textInput.CurrentTokenBeforeCursor().Value), nil
-	}
 	return nil, nil
 }
 
@@ -81,8 +84,78 @@ func (m model) Execute(input string, promptModel *prompt.Model[cmdMetadata]) (te
 			args = append(args, strings.Trim(arg, "\""))
 		}
 	}
+	background := len(args) > 0 && args[len(args)-1] == "&"
+	if background {
+		args = args[:len(args)-1]
+	}
 
-	return cmdModel{cmd: exec.Command(cmd, args...)}, nil
+	switch {
+	case cmd == "jobs":
+		type jobRow struct {
+			ID      string `table:"Job"`
+			State   string
+			Command string
+		}
+		jobs := []jobRow{}
+		for _, job := range promptModel.Jobs() {
+			state := "Running"
+			if job.Stopped {
+				state = "Stopped"
+			}
+			jobs = append(jobs, jobRow{ID: fmt.Sprintf("%%%d", job.ID), State: state, Command: job.Input})
+		}
+		table := executor.NewTableModel(jobs)
+		table.Formatter = promptModel.SuggestionManager().Formatters().Output
+		return table, nil
+	case cmd == "fg":
+		id, err := jobID(promptModel, args)
+		if err != nil {
+			return nil, err
+		}
+		return promptModel.ForegroundJob(id)
+	case cmd == "bg":
+		id, err := jobID(promptModel, args)
+		if err != nil {
+			return nil, err
+		}
+		return nil, promptModel.ResumeJob(id)
+	case cmd == "kill" && len(args) > 0 && strings.HasPrefix(args[0], "%"):
+		id, err := jobID(promptModel, args)
+		if err != nil {
+			return nil, err
+		}
+		return nil, promptModel.KillJob(id)
+	case cmd == "page" && len(args) > 0:
+		// Show long files in the pager so they don't flood the history
+		content, err := os.ReadFile(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return executor.NewPagerModel(string(content)), nil
+	case fullscreenCommands[cmd]:
+		return cmdModel{cmd: exec.Command(cmd, args...)}, nil
+	case interactiveCommands[cmd] && !background:
+		return executor.NewPtyModel(exec.Command(cmd, args...)), nil
+	}
+
+	// Stream the output of everything else into the prompt
+	process := executor.NewProcessModel(exec.Command(cmd, args...))
+	if background {
+		return prompt.RunInBackground(process), nil
+	}
+	return process, nil
+}
+
+// jobID parses a job ID in the form %1, defaulting to the most recent job.
+func jobID(promptModel *prompt.Model[cmdMetadata], args []string) (int, error) {
+	if len(args) == 0 {
+		jobs := promptModel.Jobs()
+		if len(jobs) == 0 {
+			return 0, fmt.Errorf("no current job")
+		}
+		return jobs[len(jobs)-1].ID, nil
+	}
+	return strconv.Atoi(strings.TrimPrefix(args[0], "%"))
 }
 
 func (m model) Init() tea.Cmd {
@@ -90,24 +163,42 @@ func (m model) Init() tea.Cmd {
 }
 
 func (m model) Update(msg tea.Msg) (prompt.InputHandler[cmdMetadata], tea.Cmd) {
+	// Show the exit code of the last foreground command in the prompt
+	if msg, ok := msg.(prompt.ExecutionResultMsg); ok && msg.Result.JobID == 0 {
+		if msg.Result.Failed() {
+			m.textInput.SetPrompt(fmt.Sprintf("[%d] > ", msg.Result.ExitCode))
+		} else {
+			m.textInput.SetPrompt("> ")
+		}
+	}
 	return m, nil
 }
 
 func main() {
 	fmt.Println(lipgloss.NewStyle().Foreground(lipgloss.
 		Color("6")).
-		Render("Run an external command without exiting bubbleprompt.\nCurrently this only works well with commands that produce fullscreen output."),
+		Render("Run an external command without exiting bubbleprompt.\n" +
+			"Output from commands that don't take over the screen is streamed into the prompt.\n" +
+			"Interactive commands like python3 run in a pseudo-terminal inside the prompt.\n" +
+			"End a command with & to run it in the background.\n" +
+			"Press ctrl+z to stop a command, then use bg or fg to resume it."),
 	)
 	fmt.Println()
 
 	textInput := commandinput.New[any]()
-	filenameArg := commandinput.MetadataFromPositionalArgs[any](textInput.NewPositionalArg("[filename]"))
+	filenameArg := textInput.NewPositionalArg("[filename]")
+	filenameArg.Values = &completer.PathCompleter[any]{}
+	filenameMetadata := commandinput.MetadataFromPositionalArgs[any](filenameArg)
 	suggestions := []suggestion.Suggestion[cmdMetadata]{
-		{Text: "vim", Metadata: filenameArg},
-		{Text: "emacs", Metadata: filenameArg},
-		{Text: "nano", Metadata: filenameArg},
-		{Text: "top"},
-		{Text: "htop"},
+		{Text: "vim", Group: "Programs", Metadata: filenameMetadata},
+		{Text: "emacs", Group: "Programs", Metadata: filenameMetadata},
+		{Text: "nano", Group: "Programs", Metadata: filenameMetadata},
+		{Text: "top", Group: "Programs"},
+		{Text: "htop", Group: "Programs"},
+		{Text: "page", Group: "Builtins", Description: "show a file in the pager", Metadata: filenameMetadata},
+		{Text: "jobs", Group: "Builtins", Description: "list background jobs"},
+		{Text: "fg", Group: "Builtins", Description: "move a background job to the foreground"},
+		{Text: "bg", Group: "Builtins", Description: "resume a stopped job in the background"},
 	}
 	model := model{
 		suggestions: suggestions,
@@ -115,9 +206,18 @@ func main() {
 		filterer:    completer.NewPrefixFilter[cmdMetadata](),
 	}
 
+	historyFile := filepath.Join(os.TempDir(), "bubbleprompt-exec-history")
+	commandHistory, err := history.NewFileHistory(historyFile)
+	if err != nil {
+		fmt.Printf("Could not load history\n%v\n", err)
+		os.Exit(1)
+	}
+
 	promptModel := prompt.New[cmdMetadata](
 		model,
 		textInput,
+		prompt.WithHistory[cmdMetadata](commandHistory),
+		prompt.WithAutosuggestions[cmdMetadata](),
 	)
 
 	if _, err := tea.NewProgram(promptModel, tea.WithFilter(prompt.MsgFilter)).Run(); err != nil {
@@ -1,8 +1,12 @@
 package main
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	prompt "github.com/aschey/bubbleprompt"
 	"github.com/aschey/bubbleprompt/completer"
@@ -20,7 +24,49 @@ type model struct {
 }
 
 func (m model) Complete(promptModel prompt.Model[any]) ([]suggestion.Suggestion[any], error) {
-	return m.filterer.Complete(m.textInput.CurrentTokenBeforeCursor()), nil
+	suggestions := m.filterer.Complete(m.textInput.CurrentTokenBeforeCursor())
+	for i, s := range suggestions {
+		suggestions[i].Preview = func() string {
+			return previewFile(strings.Trim(s.Text, "\""))
+		}
+	}
+	return suggestions, nil
+}
+
+// previewFile shows the start of a file or the contents of a directory.
+func previewFile(path string) string {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err.Error()
+	}
+	if info.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return err.Error()
+		}
+		names := []string{}
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		return strings.Join(names, "\n")
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err.Error()
+	}
+	defer file.Close()
+	// Only read enough to fill the preview
+	contents := make([]byte, 4096)
+	n, err := file.Read(contents)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err.Error()
+	}
+	if bytes.IndexByte(contents[:n], 0) >= 0 {
+		return "binary file"
+	}
+	// The last character may have been cut off
+	return strings.ToValidUTF8(string(contents[:n]), "")
 }
 
 func (m model) Execute(input string, promptModel *prompt.Model[any]) (tea.Model, error) {
@@ -53,6 +99,7 @@ func main() {
 	promptModel := prompt.New[any](model, textInput)
 
 	fmt.Println(lipgloss.NewStyle().Foreground(lipgloss.Color("6")).Render("Search for files or directories"))
+	fmt.Println("Select a file to preview it and use alt+↑/↓ to scroll the preview")
 	fmt.Println()
 
 	if _, err := tea.NewProgram(promptModel, tea.WithFilter(prompt.MsgFilter)).Run(); err != nil {
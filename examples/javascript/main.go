@@ -119,7 +119,8 @@ func (m model) Execute(input string, promptModel *prompt.Model[any]) (tea.Model,
 			return "", err
 		}
 
-		res, err := m.vm.RunString(input)
+		// A trailing backslash is only used to continue the input onto the next line
+		res, err := m.vm.RunString(strings.ReplaceAll(input, "\\\n", "\n"))
 		if res == nil || err != nil {
 			return "", err
 		}
@@ -145,6 +146,38 @@ func (m model) Execute(input string, promptModel *prompt.Model[any]) (tea.Model,
 	}), nil
 }
 
+// IsInputComplete continues the input onto a new line if there are unclosed brackets
+// or if the line ends with a backslash.
+func (m model) IsInputComplete(input string) bool {
+	if strings.HasSuffix(input, "\\") {
+		return false
+	}
+
+	depth := 0
+	var quote rune
+	escaped := false
+	for _, r := range input {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'' || r == '`':
+			quote = r
+		case r == '{' || r == '[' || r == '(':
+			depth++
+		case r == '}' || r == ']' || r == ')':
+			depth--
+		}
+	}
+
+	return depth <= 0
+}
+
 func (m model) Init() tea.Cmd {
 	return nil
 }
@@ -158,6 +191,7 @@ func main() {
 		parser.NewParticipleParser(participleParser),
 		lexerinput.WithDelimiterTokens[any]("Punct", "Whitespace", "And", "Or", "Eq"),
 		lexerinput.WithTokenFormatter[any](parser.NewChromaFormatter(styles.SwapOff, styleLexer)),
+		lexerinput.WithMultiline[any](),
 	)
 
 	vm := newVm()
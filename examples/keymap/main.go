@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	prompt "github.com/aschey/bubbleprompt"
+	"github.com/aschey/bubbleprompt/completer"
+	"github.com/aschey/bubbleprompt/executor"
+	"github.com/aschey/bubbleprompt/input/simpleinput"
+	"github.com/aschey/bubbleprompt/suggestion"
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type model struct {
+	suggestions []suggestion.Suggestion[any]
+	textInput   *simpleinput.Model[any]
+	help        help.Model
+	outputStyle lipgloss.Style
+	filterer    completer.Filterer[any]
+}
+
+func (m model) Complete(promptModel prompt.Model[any]) ([]suggestion.Suggestion[any], error) {
+	if len(m.textInput.Tokens()) > 1 {
+		return nil, nil
+	}
+
+	return m.filterer.Filter(m.textInput.CurrentTokenBeforeCursor(), m.suggestions), nil
+}
+
+func (m model) Execute(input string, promptModel *prompt.Model[any]) (tea.Model, error) {
+	tokens := m.textInput.WordTokenValues()
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("No selection")
+	}
+	if tokens[0] == "help" {
+		return executor.NewStringModel(m.help.FullHelpView(promptModel.KeyMap().FullHelp()) + "\n\n"), nil
+	}
+	return executor.NewStringModel("You picked: " + m.outputStyle.Render(tokens[0]) + "\n\n"), nil
+}
+
+func (m model) Init() tea.Cmd {
+	return nil
+}
+
+func (m model) Update(msg tea.Msg) (prompt.InputHandler[any], tea.Cmd) {
+	return m, nil
+}
+
+func main() {
+	textInput := simpleinput.New[any]()
+	suggestions := []suggestion.Suggestion[any]{
+		{Text: "help", Description: "show the key bindings"},
+		{Text: "banana", Description: "good with peanut butter"},
+		{Text: "jackfruit", Description: "the jack of all fruits"},
+		{Text: "lychee", Description: "better than leeches"},
+		{Text: "durian", Description: "stinky"},
+	}
+
+	keyMap := prompt.DefaultKeyMap()
+	// Cycle through suggestions with emacs-style bindings
+	keyMap.Suggestion.Next = key.NewBinding(key.WithKeys("ctrl+n"), key.WithHelp("ctrl+n", "next suggestion"))
+	keyMap.Suggestion.Previous = key.NewBinding(key.WithKeys("ctrl+p"), key.WithHelp("ctrl+p", "previous suggestion"))
+	// Don't let escape close the program
+	keyMap.Quit = key.NewBinding(key.WithKeys("ctrl+d"), key.WithHelp("ctrl+d", "quit"))
+
+	model := model{
+		suggestions: suggestions,
+		textInput:   textInput,
+		help:        help.New(),
+		outputStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("13")),
+		filterer:    completer.NewPrefixFilter[any](),
+	}
+
+	promptModel := prompt.New[any](model, textInput, prompt.WithKeyMap[any](keyMap))
+
+	fmt.Println(model.help.ShortHelpView(keyMap.ShortHelp()))
+	fmt.Println()
+
+	if _, err := tea.NewProgram(promptModel, tea.WithFilter(prompt.MsgFilter)).Run(); err != nil {
+		fmt.Printf("Could not start program\n%v\n", err)
+		os.Exit(1)
+	}
+}
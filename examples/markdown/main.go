@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	prompt "github.com/aschey/bubbleprompt"
+	"github.com/aschey/bubbleprompt/completer"
+	"github.com/aschey/bubbleprompt/executor"
+	"github.com/aschey/bubbleprompt/input/simpleinput"
+	"github.com/aschey/bubbleprompt/suggestion"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var docs = map[string]string{
+	"install": "# Installing\n\nAdd the module to your project:\n\n```sh\ngo get github.com/aschey/bubbleprompt\n```\n",
+	"usage": "# Usage\n\n" +
+		"Create an **input**, a **completer**, and an **executor**, then pass them to `prompt.New`.\n\n" +
+		"1. Return suggestions from `Complete`\n" +
+		"2. Return a `tea.Model` from `Execute`\n" +
+		"3. Run the program with `prompt.MsgFilter`\n",
+	"license": "# License\n\nReleased under the *MIT* license. See the `LICENSE` file for details.\n",
+}
+
+type model struct {
+	suggestions []suggestion.Suggestion[any]
+	textInput   *simpleinput.Model[any]
+	filterer    completer.Filterer[any]
+}
+
+func (m model) Complete(promptModel prompt.Model[any]) ([]suggestion.Suggestion[any], error) {
+	if len(m.textInput.Tokens()) > 1 {
+		return nil, nil
+	}
+
+	return m.filterer.Filter(m.textInput.CurrentTokenBeforeCursor(), m.suggestions), nil
+}
+
+func (m model) Execute(input string, promptModel *prompt.Model[any]) (tea.Model, error) {
+	tokens := m.textInput.WordTokenValues()
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("No topic selected")
+	}
+	doc, ok := docs[tokens[0]]
+	if !ok {
+		return nil, fmt.Errorf("Unknown topic %s", tokens[0])
+	}
+	return executor.NewMarkdownModel(doc), nil
+}
+
+func (m model) Init() tea.Cmd {
+	return nil
+}
+
+func (m model) Update(msg tea.Msg) (prompt.InputHandler[any], tea.Cmd) {
+	return m, nil
+}
+
+func main() {
+	textInput := simpleinput.New[any]()
+	suggestions := []suggestion.Suggestion[any]{
+		{Text: "install", Description: "how to `go get` the module"},
+		{Text: "usage", Description: "the **basics** of creating a prompt"},
+		{Text: "license", Description: "*legal* stuff"},
+	}
+
+	model := model{
+		suggestions: suggestions,
+		textInput:   textInput,
+		filterer:    completer.NewPrefixFilter[any](),
+	}
+
+	formatters := suggestion.DefaultFormatters()
+	formatters.Description.Markdown = true
+	promptModel := prompt.New[any](model, textInput, prompt.WithFormatters[any](formatters))
+
+	fmt.Println(lipgloss.NewStyle().Foreground(lipgloss.Color("6")).Render("Pick a topic to read its documentation."))
+	fmt.Println()
+
+	if _, err := tea.NewProgram(promptModel, tea.WithFilter(prompt.MsgFilter)).Run(); err != nil {
+		fmt.Printf("Could not start program\n%v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	prompt "github.com/aschey/bubbleprompt"
+	"github.com/aschey/bubbleprompt/completer"
+	"github.com/aschey/bubbleprompt/executor"
+	"github.com/aschey/bubbleprompt/input/simpleinput"
+	"github.com/aschey/bubbleprompt/suggestion"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type model struct {
+	suggestions []suggestion.Suggestion[any]
+	textInput   *simpleinput.Model[any]
+	filterer    completer.Filterer[any]
+}
+
+func (m model) Complete(promptModel prompt.Model[any]) ([]suggestion.Suggestion[any], error) {
+	if len(m.textInput.Tokens()) > 1 {
+		return nil, nil
+	}
+
+	return m.filterer.Filter(m.textInput.CurrentTokenBeforeCursor(), m.suggestions), nil
+}
+
+func (m model) Execute(input string, promptModel *prompt.Model[any]) (tea.Model, error) {
+	tokens := m.textInput.WordTokenValues()
+	if len(tokens) == 0 || tokens[0] != "migrate" {
+		return nil, fmt.Errorf("Unknown command")
+	}
+	tables := 10
+	if len(tokens) > 1 {
+		var err error
+		if tables, err = strconv.Atoi(tokens[1]); err != nil {
+			return nil, fmt.Errorf("Invalid number of tables: %w", err)
+		}
+	}
+	return executor.NewProgressModel(func(ctx context.Context, reporter executor.ProgressReporter) (string, error) {
+		return migrate(ctx, reporter, tables)
+	}), nil
+}
+
+// migrate pretends to migrate some database tables
+func migrate(ctx context.Context, reporter executor.ProgressReporter, tables int) (string, error) {
+	for i := 1; i <= tables; i++ {
+		reporter.SetStatus(fmt.Sprintf("migrating table %d of %d", i, tables))
+		select {
+		case <-ctx.Done():
+			return fmt.Sprintf("Migration cancelled after %d tables", i-1), ctx.Err()
+		case <-time.After(500 * time.Millisecond):
+		}
+		reporter.Logf("migrated table_%d", i)
+		reporter.SetPercent(float64(i) / float64(tables))
+	}
+	return fmt.Sprintf("Migrated %d tables", tables), nil
+}
+
+func (m model) Init() tea.Cmd {
+	return nil
+}
+
+func (m model) Update(msg tea.Msg) (prompt.InputHandler[any], tea.Cmd) {
+	return m, nil
+}
+
+func main() {
+	textInput := simpleinput.New[any]()
+	suggestions := []suggestion.Suggestion[any]{
+		{Text: "migrate", Description: "migrate some tables"},
+	}
+
+	model := model{
+		suggestions: suggestions,
+		textInput:   textInput,
+		filterer:    completer.NewPrefixFilter[any](),
+	}
+
+	promptModel := prompt.New[any](model, textInput)
+
+	fmt.Println(lipgloss.NewStyle().Foreground(lipgloss.Color("6")).Render(
+		"Run migrate [tables] to watch the progress. Press ctrl+c to cancel the migration.",
+	))
+	fmt.Println()
+
+	if _, err := tea.NewProgram(promptModel, tea.WithFilter(prompt.MsgFilter)).Run(); err != nil {
+		fmt.Printf("Could not start program\n%v\n", err)
+		os.Exit(1)
+	}
+}
@@ -38,6 +38,15 @@ func (m model) Complete(
 				Long:           "days",
 				ArgPlaceholder: m.textInput.NewFlagPlaceholder("<int>"),
 				Description:    "Forecast days",
+				ValueType:      commandinput.FlagValueInt,
+			},
+			{
+				Short:          "u",
+				Long:           "units",
+				ArgPlaceholder: m.textInput.NewFlagPlaceholder("<metric|imperial>"),
+				Description:    "Temperature units",
+				ValueType:      commandinput.FlagValueEnum,
+				EnumValues:     []string{"metric", "imperial"},
 			},
 		}
 		return m.textInput.FlagSuggestions(
@@ -56,7 +65,6 @@ func (m model) Complete(
 func (m model) Execute(input string, promptModel *prompt.Model[cmdMetadata]) (tea.Model, error) {
 	parsed := m.textInput.ParsedValue()
 	args := parsed.Args
-	flags := parsed.Flags
 	if len(args) == 0 {
 		return nil, fmt.Errorf("1 argument required")
 	}
@@ -65,24 +73,25 @@ func (m model) Execute(input string, promptModel *prompt.Model[cmdMetadata]) (te
 	case "get":
 		switch arg.Value {
 		case "weather":
-			days := "1"
-			if len(flags) > 0 {
-				flag := flags[0]
-				if flag.Name.Value == "-d" || flag.Name.Value == "--days" {
-					if flag.Value == nil {
-						return nil, fmt.Errorf("flag value required")
-					}
-					_, err := strconv.ParseInt(flag.Value.Value, 10, 64)
-					if err != nil {
-						return nil, fmt.Errorf("flag value must be a valid int")
-					}
-					days = flag.Value.Value
+			if err := m.textInput.ValidateFlags(); err != nil {
+				return nil, err
+			}
+			days := 1
+			if flag, ok := parsed.FindFlag("d", "days"); ok {
+				flagDays, err := flag.IntValue()
+				if err != nil {
+					return nil, fmt.Errorf("flag value must be a valid int")
 				}
+				days = flagDays
+			}
+			units := "metric"
+			if flag, ok := parsed.FindFlag("u", "units"); ok {
+				units = flag.StringValue()
 			}
-			days = m.executorValueStyle.Render(days)
+			daysText := m.executorValueStyle.Render(strconv.Itoa(days))
 			value := m.executorValueStyle.Render("cloudy with a chance of meatballs")
 			return executor.NewStringModel(
-				fmt.Sprintf("weather for the next %s day(s) is: %s", days, value),
+				fmt.Sprintf("weather for the next %s day(s) in %s units is: %s", daysText, units, value),
 			), nil
 		case "secret":
 			return executor.NewStringModel(
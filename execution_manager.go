@@ -1,33 +1,53 @@
 package prompt
 
 import (
+	"sync/atomic"
+	"time"
+
 	"github.com/aschey/bubbleprompt/executor"
+	"github.com/aschey/bubbleprompt/suggestion"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
 )
 
 type ExecutorFinishedMsg tea.Model
 
+var lastExecutorID atomic.Int64
+
 type executionManager struct {
 	inner          tea.Model
-	errorTextStyle lipgloss.Style
+	errorFormatter suggestion.ErrorFormatter
 	err            error
+	input          string
+	start          time.Time
+	// id is used to tag the messages from the executor's commands
+	id int
+	// jobID is set once the executor is moved to the background
+	jobID int
+	// stopped is set when the job shouldn't receive messages until it's resumed
+	stopped bool
+	// pending contains the messages that were sent while the job was stopped
+	pending []tea.Msg
 }
 
 func newExecutorManager(
 	inner tea.Model,
-	errorTextStyle lipgloss.Style,
+	input string,
+	errorFormatter suggestion.ErrorFormatter,
 	err error,
 ) *executionManager {
 	return &executionManager{
 		inner:          inner,
-		errorTextStyle: errorTextStyle,
+		id:             int(lastExecutorID.Add(1)),
+		input:          input,
+		start:          time.Now(),
+		errorFormatter: errorFormatter,
 		err:            err,
 	}
 }
 
+// The commands returned from the execution manager are tagged with its ID so their messages only reach this executor.
 func (m executionManager) Init() tea.Cmd {
-	return m.inner.Init()
+	return wrapJobCmd(m.id, m.inner.Init())
 }
 
 func (m executionManager) Update(msg tea.Msg) (executionManager, tea.Cmd) {
@@ -35,15 +55,36 @@ func (m executionManager) Update(msg tea.Msg) (executionManager, tea.Cmd) {
 	m.inner = inner
 	if msg, ok := msg.(executor.ErrorMsg); ok {
 		m.err = error(msg)
-		return m, tea.Quit
+		return m, wrapJobCmd(m.id, tea.Quit)
 	} else {
-		return m, cmd
+		return m, wrapJobCmd(m.id, cmd)
+	}
+}
+
+func (m executionManager) interrupt() (tea.Cmd, bool) {
+	if interruptible, ok := m.inner.(executor.Interruptible); ok {
+		return wrapJobCmd(m.id, interruptible.Interrupt()), true
+	}
+	return nil, false
+}
+
+func (m executionManager) stop() tea.Cmd {
+	if stoppable, ok := m.inner.(executor.Stoppable); ok {
+		return wrapJobCmd(m.id, stoppable.Stop())
+	}
+	return nil
+}
+
+func (m executionManager) resume() tea.Cmd {
+	if stoppable, ok := m.inner.(executor.Stoppable); ok {
+		return wrapJobCmd(m.id, stoppable.Continue())
 	}
+	return nil
 }
 
 func (m executionManager) View() string {
 	if m.err != nil {
-		return m.errorTextStyle.Render(m.err.Error()) + "\n"
+		return m.errorFormatter.Render(m.err) + "\n"
 	} else {
 		return m.inner.View()
 	}
@@ -0,0 +1,99 @@
+package prompt
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aschey/bubbleprompt/executor"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ErrJobKilled is the error for a background job that was stopped with [Model.KillJob]
+// when the job's model doesn't support being interrupted.
+var ErrJobKilled = errors.New("job killed")
+
+// DefaultMaxResults is the number of execution results that are kept by default.
+const DefaultMaxResults = 100
+
+// ExecutionResult describes a finished execution.
+type ExecutionResult struct {
+	// Input is the text that was submitted.
+	Input string
+	// Start is the time the input was submitted.
+	Start time.Time
+	// End is the time the executor finished.
+	End time.Time
+	// ExitCode is the exit status of the executor.
+	// It comes from [executor.ExitCoder] if the executor implements it.
+	// If there was an error and the executor didn't report a failure, it's set to 1.
+	ExitCode int
+	// Err is the error returned from [InputHandler.Execute] or sent as an [executor.ErrorMsg].
+	Err error
+	// Output is the final output of the executor.
+	Output string
+	// Model is the final state of the executor.
+	Model tea.Model
+	// JobID is the ID of the job if the executor was run in the background.
+	JobID int
+}
+
+// Duration returns how long the execution took.
+func (r ExecutionResult) Duration() time.Duration {
+	return r.End.Sub(r.Start)
+}
+
+// Failed returns whether the execution returned an error or a non-zero exit code.
+func (r ExecutionResult) Failed() bool {
+	return r.Err != nil || r.ExitCode != 0
+}
+
+// ExecutionResultMsg is sent to the [InputHandler] whenever an execution finishes, including background jobs.
+type ExecutionResultMsg struct {
+	Result ExecutionResult
+}
+
+func (m *Model[T]) addResult(result ExecutionResult) tea.Cmd {
+	m.results = append(m.results, result)
+	if m.maxResults > 0 && len(m.results) > m.maxResults {
+		m.results = m.results[len(m.results)-m.maxResults:]
+	}
+	return func() tea.Msg { return ExecutionResultMsg{Result: result} }
+}
+
+// Results returns the results of previous executions, oldest first.
+// The number of results that are kept can be configured with [WithMaxResults].
+func (m Model[T]) Results() []ExecutionResult {
+	return m.results
+}
+
+// LastResult returns the result of the most recent execution.
+// It returns false if nothing has been executed yet.
+func (m Model[T]) LastResult() (ExecutionResult, bool) {
+	if len(m.results) == 0 {
+		return ExecutionResult{}, false
+	}
+	return m.results[len(m.results)-1], true
+}
+
+func (m executionManager) result(err error) ExecutionResult {
+	if err == nil {
+		err = m.err
+	}
+	exitCode := 0
+	if exitCoder, ok := m.inner.(executor.ExitCoder); ok {
+		exitCode = exitCoder.ExitCode()
+	}
+	if err != nil && exitCode == 0 {
+		exitCode = 1
+	}
+	return ExecutionResult{
+		Input:    m.input,
+		Start:    m.start,
+		End:      time.Now(),
+		ExitCode: exitCode,
+		Err:      err,
+		Output:   m.View(),
+		Model:    m.inner,
+		JobID:    m.jobID,
+	}
+}
@@ -0,0 +1,153 @@
+package prompt
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aschey/bubbleprompt/executor"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var errTest = errors.New("test error")
+
+// exitModel finishes immediately with the given exit code and error.
+type exitModel struct {
+	code int
+	err  error
+}
+
+func (m exitModel) Init() tea.Cmd {
+	if m.err != nil {
+		return func() tea.Msg { return executor.ErrorMsg(m.err) }
+	}
+	return tea.Quit
+}
+
+func (m exitModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	return m, nil
+}
+
+func (m exitModel) View() string {
+	return "done\n"
+}
+
+func (m exitModel) ExitCode() int {
+	return m.code
+}
+
+func TestExecutionResult(t *testing.T) {
+	tests := []struct {
+		name     string
+		execute  func(input string, prompt *Model[any]) (tea.Model, error)
+		exitCode int
+		err      error
+		output   string
+	}{
+		{name: "success", output: "a\n"},
+		{
+			name:     "execute error",
+			execute:  func(string, *Model[any]) (tea.Model, error) { return nil, errTest },
+			exitCode: 1,
+			err:      errTest,
+		},
+		{
+			name:     "exit code",
+			execute:  func(string, *Model[any]) (tea.Model, error) { return exitModel{code: 3}, nil },
+			exitCode: 3,
+			output:   "done\n",
+		},
+		{
+			name:     "error message",
+			execute:  func(string, *Model[any]) (tea.Model, error) { return exitModel{err: errTest}, nil },
+			exitCode: 1,
+			err:      errTest,
+			output:   "done\n",
+		},
+		{
+			name:     "error message with exit code",
+			execute:  func(string, *Model[any]) (tea.Model, error) { return exitModel{code: 2, err: errTest}, nil },
+			exitCode: 2,
+			err:      errTest,
+			output:   "done\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler := &testHandler{execute: test.execute}
+			p := newTestPrompt(t, handler)
+			p.submit("a")
+			if len(handler.results) != 1 {
+				t.Fatalf("results sent to the handler = %d, want 1", len(handler.results))
+			}
+			result, ok := p.model.LastResult()
+			if !ok {
+				t.Fatal("no last result")
+			}
+			if result.Input != "a" {
+				t.Errorf("input = %q, want %q", result.Input, "a")
+			}
+			if result.ExitCode != test.exitCode {
+				t.Errorf("exit code = %d, want %d", result.ExitCode, test.exitCode)
+			}
+			if !errors.Is(result.Err, test.err) {
+				t.Errorf("error = %v, want %v", result.Err, test.err)
+			}
+			if failed := test.exitCode != 0 || test.err != nil; result.Failed() != failed {
+				t.Errorf("failed = %v, want %v", result.Failed(), failed)
+			}
+			if test.err == nil && result.Output != test.output {
+				t.Errorf("output = %q, want %q", result.Output, test.output)
+			}
+			if result.Duration() < 0 {
+				t.Errorf("duration = %v, want a positive duration", result.Duration())
+			}
+			if handler.results[0].Input != result.Input {
+				t.Errorf("handler result input = %q, want %q", handler.results[0].Input, result.Input)
+			}
+		})
+	}
+}
+
+func TestMaxResults(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxResults int
+		want       []string
+	}{
+		{name: "default", maxResults: DefaultMaxResults, want: []string{"a", "b", "c"}},
+		{name: "trimmed", maxResults: 2, want: []string{"b", "c"}},
+		{name: "one", maxResults: 1, want: []string{"c"}},
+		{name: "zero is unlimited", maxResults: 0, want: []string{"a", "b", "c"}},
+		{name: "negative is unlimited", maxResults: -1, want: []string{"a", "b", "c"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler := &testHandler{}
+			p := newTestPrompt(t, handler, WithMaxResults[any](test.maxResults))
+			if _, ok := p.model.LastResult(); ok {
+				t.Error("last result before executing anything")
+			}
+			for _, input := range []string{"a", "b", "c"} {
+				p.submit(input)
+			}
+			results := p.model.Results()
+			inputs := []string{}
+			for _, result := range results {
+				inputs = append(inputs, result.Input)
+			}
+			if len(inputs) != len(test.want) {
+				t.Fatalf("results = %q, want %q", inputs, test.want)
+			}
+			for i := range inputs {
+				if inputs[i] != test.want[i] {
+					t.Errorf("results = %q, want %q", inputs, test.want)
+					break
+				}
+			}
+			// Every result is still sent to the input handler
+			if len(handler.results) != 3 {
+				t.Errorf("results sent to the handler = %d, want 3", len(handler.results))
+			}
+		})
+	}
+}
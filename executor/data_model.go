@@ -0,0 +1,115 @@
+package executor
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/aschey/bubbleprompt/internal"
+	"github.com/aschey/bubbleprompt/suggestion"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"gopkg.in/yaml.v3"
+)
+
+// DataModel renders a value as a syntax-highlighted JSON or YAML document.
+// Long lines are wrapped to fit within the width of the terminal.
+type DataModel struct {
+	document string
+	language string
+	err      error
+	width    int
+	// Formatter handles styling for the document.
+	Formatter suggestion.OutputFormatter
+}
+
+// NewJSONModel creates a model that renders the value as indented JSON.
+func NewJSONModel(value any) DataModel {
+	document, err := json.MarshalIndent(value, "", "  ")
+	return newDataModel(string(document), "json", err)
+}
+
+// NewYAMLModel creates a model that renders the value as YAML.
+func NewYAMLModel(value any) DataModel {
+	document, err := yaml.Marshal(value)
+	return newDataModel(string(document), "yaml", err)
+}
+
+func newDataModel(document string, language string, err error) DataModel {
+	return DataModel{
+		document:  document,
+		language:  language,
+		err:       err,
+		Formatter: suggestion.DefaultFormatters().Output,
+	}
+}
+
+func (m DataModel) Init() tea.Cmd {
+	if m.err != nil {
+		return func() tea.Msg { return ErrorMsg(m.err) }
+	}
+	return nil
+}
+
+func (m DataModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// The prompt sends the terminal size after the model is initialized,
+	// so the document can be rendered once the width is known
+	if msg, ok := msg.(tea.WindowSizeMsg); ok {
+		m.width = msg.Width
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m DataModel) tokenStyle(tokenType chroma.TokenType) (lipgloss.Style, bool) {
+	switch {
+	case tokenType == chroma.NameTag:
+		return m.Formatter.DataKey, true
+	case tokenType == chroma.KeywordConstant:
+		return m.Formatter.DataLiteral, true
+	case tokenType.InSubCategory(chroma.LiteralString), tokenType == chroma.Literal:
+		return m.Formatter.DataString, true
+	case tokenType.InSubCategory(chroma.LiteralNumber):
+		return m.Formatter.DataNumber, true
+	case tokenType == chroma.Punctuation:
+		return m.Formatter.DataPunctuation, true
+	}
+	return lipgloss.Style{}, false
+}
+
+func (m DataModel) highlight() string {
+	lexer := lexers.Get(m.language)
+	iterator, err := lexer.Tokenise(nil, m.document)
+	if err != nil {
+		return m.document
+	}
+	view := strings.Builder{}
+	for _, token := range iterator.Tokens() {
+		style, ok := m.tokenStyle(token.Type)
+		if !ok {
+			view.WriteString(token.Value)
+			continue
+		}
+		// Style each line separately so lipgloss doesn't pad multiline values
+		lines := strings.Split(token.Value, "\n")
+		for i, line := range lines {
+			if line != "" {
+				view.WriteString(style.Render(line))
+			}
+			if i < len(lines)-1 {
+				view.WriteString("\n")
+			}
+		}
+	}
+	return view.String()
+}
+
+func (m DataModel) View() string {
+	view := m.highlight()
+	if m.width > 0 {
+		view = ansi.Wrap(view, m.width, "")
+	}
+	return internal.AddNewlineIfMissing(view)
+}
@@ -0,0 +1,7 @@
+package executor
+
+// ExitCoder can optionally be implemented by an executor model to report an exit status once it finishes.
+// Zero means success.
+type ExitCoder interface {
+	ExitCode() int
+}
@@ -0,0 +1,14 @@
+package executor
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// Interruptible can optionally be implemented by an executor model to handle the prompt's interrupt key
+// (ctrl+c by default).
+// Interrupt should ask the work to stop. The model keeps running until it returns [tea.Quit] as usual.
+// Executor models that don't implement this interface are stopped immediately when interrupted.
+// The prompt stops listening to them, but any work that they already started, such as a goroutine
+// running inside a [tea.Cmd], keeps running in the background until it finishes on its own.
+// Implement this interface if the work can be cancelled.
+type Interruptible interface {
+	Interrupt() tea.Cmd
+}
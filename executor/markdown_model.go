@@ -0,0 +1,44 @@
+package executor
+
+import (
+	"github.com/aschey/bubbleprompt/internal"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// MarkdownModel renders a Markdown document with glamour.
+// The document is wrapped to fit within the width of the terminal and styled to match the terminal's
+// color profile and background color.
+type MarkdownModel struct {
+	markdown string
+	rendered string
+}
+
+// NewMarkdownModel creates a model that renders the Markdown document.
+func NewMarkdownModel(markdown string) MarkdownModel {
+	return MarkdownModel{markdown: markdown}
+}
+
+func (m MarkdownModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m MarkdownModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// The prompt sends the terminal size after the model is initialized,
+	// so the document can be rendered once the width is known
+	if msg, ok := msg.(tea.WindowSizeMsg); ok {
+		rendered, err := internal.RenderMarkdown(m.markdown, msg.Width)
+		if err != nil {
+			return m, func() tea.Msg { return ErrorMsg(err) }
+		}
+		m.rendered = rendered
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m MarkdownModel) View() string {
+	if m.rendered == "" {
+		return ""
+	}
+	return internal.AddNewlineIfMissing(m.rendered)
+}
@@ -0,0 +1,33 @@
+package executor_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aschey/bubbleprompt/executor"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func ExampleNewMarkdownModel() {
+	model := executor.NewMarkdownModel("# Notes\n\nThe document is wrapped to fit the terminal.\n\n- one\n- two\n")
+	// Nothing is rendered until the prompt sends the terminal size
+	fmt.Printf("%q\n", model.View())
+	updated, cmd := model.Update(tea.WindowSizeMsg{Width: 24, Height: 10})
+	_, quit := cmd().(tea.QuitMsg)
+	fmt.Println(quit)
+	// Glamour pads each line to the full width
+	for _, line := range strings.Split(updated.View(), "\n") {
+		fmt.Println(strings.TrimRight(line, " "))
+	}
+	// Output:
+	// ""
+	// true
+	//   # Notes
+	//
+	//   The document is
+	//   wrapped to fit the
+	//   terminal.
+	//
+	//   • one
+	//   • two
+}
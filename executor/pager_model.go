@@ -0,0 +1,320 @@
+package executor
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync/atomic"
+
+	"github.com/aschey/bubbleprompt/internal"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+var lastPagerID atomic.Int64
+
+// DefaultPagerHistoryLines is the number of lines of a paged output that are kept in the prompt's history by default.
+const DefaultPagerHistoryLines = 10
+
+// PagerKeyMap defines the keys used to navigate a [PagerModel].
+// Page up and page down aren't bound by default since they're used by the prompt's renderer.
+type PagerKeyMap struct {
+	Up           key.Binding
+	Down         key.Binding
+	PageUp       key.Binding
+	PageDown     key.Binding
+	HalfPageUp   key.Binding
+	HalfPageDown key.Binding
+	Top          key.Binding
+	Bottom       key.Binding
+	Search       key.Binding
+	NextMatch    key.Binding
+	PrevMatch    key.Binding
+	Quit         key.Binding
+}
+
+// DefaultPagerKeyMap returns the default pager key bindings which are similar to less.
+func DefaultPagerKeyMap() PagerKeyMap {
+	return PagerKeyMap{
+		Up:           key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "up")),
+		Down:         key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "down")),
+		PageUp:       key.NewBinding(key.WithKeys("b", "ctrl+b"), key.WithHelp("b", "page up")),
+		PageDown:     key.NewBinding(key.WithKeys(" ", "f", "ctrl+f"), key.WithHelp("space", "page down")),
+		HalfPageUp:   key.NewBinding(key.WithKeys("u", "ctrl+u"), key.WithHelp("u", "half page up")),
+		HalfPageDown: key.NewBinding(key.WithKeys("d", "ctrl+d"), key.WithHelp("d", "half page down")),
+		Top:          key.NewBinding(key.WithKeys("g", "home"), key.WithHelp("g", "top")),
+		Bottom:       key.NewBinding(key.WithKeys("G", "end"), key.WithHelp("G", "bottom")),
+		Search:       key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "search")),
+		NextMatch:    key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "next match")),
+		PrevMatch:    key.NewBinding(key.WithKeys("N"), key.WithHelp("N", "previous match")),
+		Quit:         key.NewBinding(key.WithKeys("q", "esc"), key.WithHelp("q", "quit")),
+	}
+}
+
+type pagerQuitMsg struct {
+	id int64
+}
+
+// PagerModel shows output that's taller than the terminal in a scrollable pager with search.
+// Output that fits in the terminal is shown in full without starting the pager.
+// Once the pager is closed, only the first few lines of the output or the summary are kept in the prompt's history
+// to avoid filling it with long outputs.
+type PagerModel struct {
+	id       int64
+	content  string
+	lines    []string
+	viewport viewport.Model
+	search   textinput.Model
+	// searching is true while the search query is being typed
+	searching bool
+	query     string
+	// pattern matches the query without regard to case
+	pattern  *regexp.Regexp
+	matches  []int
+	match    int
+	ready    bool
+	fits     bool
+	finished bool
+	// KeyMap defines the keys used to navigate the pager.
+	KeyMap PagerKeyMap
+	// HistoryLines is the number of lines that are kept in the prompt's history once the pager is closed.
+	HistoryLines int
+	// Summary replaces the lines that are kept in the prompt's history if it's set.
+	Summary string
+	// StatusStyle handles styling for the status line below the output.
+	StatusStyle lipgloss.Style
+	// MatchStyle handles styling for search matches.
+	MatchStyle lipgloss.Style
+	// SummaryStyle handles styling for the line that shows how many lines were omitted from the history.
+	SummaryStyle lipgloss.Style
+}
+
+// NewPagerModel creates a model that shows the content in a pager if it doesn't fit in the terminal.
+func NewPagerModel(content string) PagerModel {
+	search := textinput.New()
+	search.Prompt = "/"
+	return PagerModel{
+		id:           lastPagerID.Add(1),
+		content:      strings.TrimRight(content, "\n"),
+		search:       search,
+		KeyMap:       DefaultPagerKeyMap(),
+		HistoryLines: DefaultPagerHistoryLines,
+		StatusStyle:  lipgloss.NewStyle().Reverse(true),
+		MatchStyle:   lipgloss.NewStyle().Reverse(true),
+		SummaryStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("245")),
+	}
+}
+
+func (m PagerModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m PagerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.resize(msg.Width, msg.Height)
+		if !m.ready {
+			m.ready = true
+			if m.fits {
+				// Nothing to page so the output can go straight to the history
+				m.finished = true
+				return m, tea.Quit
+			}
+		}
+	case pagerQuitMsg:
+		if msg.id == m.id {
+			m.finished = true
+			return m, tea.Quit
+		}
+	case tea.KeyMsg:
+		if !m.ready || m.finished {
+			return m, nil
+		}
+		if m.searching {
+			return m.updateSearch(msg)
+		}
+		return m.updateKey(msg)
+	}
+	return m, nil
+}
+
+func (m *PagerModel) resize(width int, height int) {
+	m.lines = strings.Split(m.content, "\n")
+	if width > 0 {
+		m.lines = strings.Split(ansi.Wrap(m.content, width, ""), "\n")
+	}
+	// Leave room for the status line and the line below it
+	m.viewport.Width = width
+	m.viewport.Height = max(height-2, 1)
+	m.fits = len(m.lines) <= m.viewport.Height
+	m.viewport.KeyMap = viewport.KeyMap{
+		Up:           m.KeyMap.Up,
+		Down:         m.KeyMap.Down,
+		PageUp:       m.KeyMap.PageUp,
+		PageDown:     m.KeyMap.PageDown,
+		HalfPageUp:   m.KeyMap.HalfPageUp,
+		HalfPageDown: m.KeyMap.HalfPageDown,
+	}
+	m.findMatches()
+	m.updateContent()
+}
+
+func (m PagerModel) updateKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.KeyMap.Quit):
+		m.finished = true
+		return m, tea.Quit
+	case key.Matches(msg, m.KeyMap.Search):
+		m.searching = true
+		m.search.SetValue("")
+		return m, m.search.Focus()
+	case key.Matches(msg, m.KeyMap.NextMatch):
+		m.gotoMatch(m.match + 1)
+	case key.Matches(msg, m.KeyMap.PrevMatch):
+		m.gotoMatch(m.match - 1)
+	case key.Matches(msg, m.KeyMap.Top):
+		m.viewport.GotoTop()
+	case key.Matches(msg, m.KeyMap.Bottom):
+		m.viewport.GotoBottom()
+	default:
+		var cmd tea.Cmd
+		m.viewport, cmd = m.viewport.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m PagerModel) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.searching = false
+		m.search.Blur()
+		m.query = m.search.Value()
+		m.findMatches()
+		m.updateContent()
+		// Start at the first match that's on or after the current line
+		m.match = len(m.matches)
+		for i, line := range m.matches {
+			if line >= m.viewport.YOffset {
+				m.match = i
+				break
+			}
+		}
+		m.gotoMatch(m.match)
+		return m, nil
+	case tea.KeyEsc:
+		m.searching = false
+		m.search.Blur()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.search, cmd = m.search.Update(msg)
+	return m, cmd
+}
+
+func (m *PagerModel) findMatches() {
+	m.matches = nil
+	if m.query == "" {
+		return
+	}
+	m.pattern = regexp.MustCompile("(?i)" + regexp.QuoteMeta(m.query))
+	for i, line := range m.lines {
+		if m.pattern.MatchString(ansi.Strip(line)) {
+			m.matches = append(m.matches, i)
+		}
+	}
+}
+
+func (m *PagerModel) gotoMatch(match int) {
+	if len(m.matches) == 0 {
+		return
+	}
+	// Wrap around like less does
+	m.match = (match + len(m.matches)) % len(m.matches)
+	m.viewport.SetYOffset(m.matches[m.match])
+}
+
+func (m *PagerModel) updateContent() {
+	if len(m.matches) == 0 {
+		m.viewport.SetContent(strings.Join(m.lines, "\n"))
+		return
+	}
+	lines := make([]string, len(m.lines))
+	copy(lines, m.lines)
+	for _, index := range m.matches {
+		lines[index] = m.highlight(lines[index])
+	}
+	m.viewport.SetContent(strings.Join(lines, "\n"))
+}
+
+// highlight styles each occurrence of the search query in the line.
+// Lines that are already styled are left alone since the matches can't be styled without breaking the existing styles.
+func (m PagerModel) highlight(line string) string {
+	if strings.Contains(line, "\x1b") {
+		return line
+	}
+	// Matching on the original line keeps the positions correct when case folding changes the length of the text
+	return m.pattern.ReplaceAllStringFunc(line, func(match string) string {
+		return m.MatchStyle.Render(match)
+	})
+}
+
+// Interrupt is part of the [Interruptible] interface.
+// It closes the pager.
+func (m PagerModel) Interrupt() tea.Cmd {
+	return func() tea.Msg {
+		return pagerQuitMsg{id: m.id}
+	}
+}
+
+func (m PagerModel) statusView() string {
+	if m.searching {
+		return m.search.View()
+	}
+	first := m.viewport.YOffset + 1
+	last := min(m.viewport.YOffset+m.viewport.Height, len(m.lines))
+	status := fmt.Sprintf("lines %d-%d of %d (%.0f%%)", first, last, len(m.lines), m.viewport.ScrollPercent()*100)
+	switch {
+	case m.query != "" && len(m.matches) == 0:
+		status += " • pattern not found"
+	case len(m.matches) > 0:
+		status += fmt.Sprintf(" • match %d of %d", m.match+1, len(m.matches))
+	}
+	status += fmt.Sprintf(
+		" • %s %s • %s %s",
+		m.KeyMap.Search.Help().Key,
+		m.KeyMap.Search.Help().Desc,
+		m.KeyMap.Quit.Help().Key,
+		m.KeyMap.Quit.Help().Desc,
+	)
+	return m.StatusStyle.Render(ansi.Truncate(status, m.viewport.Width, "…"))
+}
+
+func (m PagerModel) historyView() string {
+	if m.fits {
+		return internal.AddNewlineIfMissing(strings.Join(m.lines, "\n"))
+	}
+	if m.Summary != "" {
+		return internal.AddNewlineIfMissing(m.Summary)
+	}
+	lines := m.lines[:min(m.HistoryLines, len(m.lines))]
+	view := strings.Join(lines, "\n")
+	if omitted := len(m.lines) - len(lines); omitted > 0 {
+		view += "\n" + m.SummaryStyle.Render(fmt.Sprintf("… %d more lines", omitted))
+	}
+	return internal.AddNewlineIfMissing(view)
+}
+
+func (m PagerModel) View() string {
+	if m.finished {
+		return m.historyView()
+	}
+	if !m.ready {
+		return ""
+	}
+	return m.viewport.View() + "\n" + m.statusView() + "\n"
+}
@@ -0,0 +1,37 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestPagerHighlight(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		line  string
+		want  string
+	}{
+		{name: "ascii", query: "foo", line: "Foo bar foo", want: "[Foo] bar [foo]"},
+		// İ is longer once it's lowercased
+		{name: "dotted capital i", query: "x", line: "\u0130\u0130x", want: "\u0130\u0130[x]"},
+		{name: "match dotted capital i", query: "\u0130s", line: "\u0130stanbul", want: "[\u0130s]tanbul"},
+		// The Kelvin sign is shorter once it's lowercased
+		{name: "kelvin sign", query: "k", line: "5 \u212a and k", want: "5 [\u212a] and [k]"},
+		{name: "after kelvin sign", query: "and", line: "5 \u212a AND k", want: "5 \u212a [AND] k"},
+		{name: "special characters", query: "a.b", line: "a.b axb", want: "[a.b] axb"},
+		{name: "styled line", query: "foo", line: "\x1b[1mfoo\x1b[0m", want: "\x1b[1mfoo\x1b[0m"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			model := NewPagerModel(test.line)
+			model.MatchStyle = lipgloss.NewStyle().Transform(func(s string) string { return "[" + s + "]" })
+			model.query = test.query
+			model.findMatches()
+			if got := model.highlight(test.line); got != test.want {
+				t.Errorf("highlight(%q) = %q, want %q", test.line, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,281 @@
+package executor
+
+import (
+	"errors"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/aschey/bubbleprompt/internal"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var lastProcessID atomic.Int64
+
+// Maximum number of lines that are sent to the model in a single update
+const maxLinesPerUpdate = 256
+
+// Output that doesn't end in a newline, such as an input prompt,
+// is shown once the command hasn't written anything else for this long
+const partialLineDelay = 100 * time.Millisecond
+
+type processLine struct {
+	text   string
+	stderr bool
+	// partial is set when the rest of the line hasn't been written yet
+	partial bool
+}
+
+type processOutputMsg struct {
+	id    int64
+	lines []processLine
+}
+
+type processExitMsg struct {
+	id       int64
+	exitCode int
+	status   string
+	err      error
+}
+
+// ProcessModel runs a command and streams its output into the prompt line by line while the command is running.
+// Output that doesn't end in a newline, such as an input prompt, is shown once the command stops writing.
+// ANSI escape sequences in the output are preserved, but note that many programs disable colors
+// when they aren't writing to a terminal.
+// Commands that take over the whole screen should use [tea.ExecProcess] instead.
+type ProcessModel struct {
+	cmd      *exec.Cmd
+	id       int64
+	output   chan processLine
+	lines    []processLine
+	finished bool
+	exitCode int
+	status   string
+	// StderrStyle handles styling for lines written to stderr.
+	StderrStyle lipgloss.Style
+	// ExitCodeStyle handles styling for the message that's shown when the command fails.
+	ExitCodeStyle lipgloss.Style
+}
+
+// NewProcessModel creates a model that runs the command when it's initialized.
+// The command's stdout and stderr must not be set.
+func NewProcessModel(cmd *exec.Cmd) ProcessModel {
+	return ProcessModel{
+		cmd:           cmd,
+		id:            lastProcessID.Add(1),
+		output:        make(chan processLine, maxLinesPerUpdate),
+		StderrStyle:   lipgloss.NewStyle().Foreground(lipgloss.Color("9")),
+		ExitCodeStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("9")),
+	}
+}
+
+func (m ProcessModel) Init() tea.Cmd {
+	return func() tea.Msg {
+		stdout, err := m.cmd.StdoutPipe()
+		if err != nil {
+			return ErrorMsg(err)
+		}
+		stderr, err := m.cmd.StderrPipe()
+		if err != nil {
+			return ErrorMsg(err)
+		}
+		setProcessGroup(m.cmd)
+		if err := m.cmd.Start(); err != nil {
+			return ErrorMsg(err)
+		}
+
+		wg := sync.WaitGroup{}
+		wg.Add(2)
+		go m.readLines(stdout, false, &wg)
+		go m.readLines(stderr, true, &wg)
+		go func() {
+			wg.Wait()
+			close(m.output)
+		}()
+
+		return m.waitForOutput()
+	}
+}
+
+func (m ProcessModel) readLines(reader io.Reader, stderr bool, wg *sync.WaitGroup) {
+	defer wg.Done()
+	chunks := make(chan string)
+	go func() {
+		defer close(chunks)
+		buf := make([]byte, 4096)
+		for {
+			n, err := reader.Read(buf)
+			if n > 0 {
+				chunks <- string(buf[:n])
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	pending := ""
+	flushTimer := time.NewTimer(partialLineDelay)
+	flushTimer.Stop()
+	defer flushTimer.Stop()
+	for {
+		select {
+		case chunk, ok := <-chunks:
+			if !ok {
+				if len(pending) > 0 {
+					m.output <- processLine{text: strings.TrimRight(pending, "\r"), stderr: stderr}
+				}
+				return
+			}
+			pending += chunk
+			for {
+				end := strings.IndexByte(pending, '\n')
+				if end < 0 {
+					break
+				}
+				m.output <- processLine{text: strings.TrimRight(pending[:end], "\r"), stderr: stderr}
+				pending = pending[end+1:]
+			}
+			if len(pending) > 0 {
+				flushTimer.Reset(partialLineDelay)
+			}
+		case <-flushTimer.C:
+			if len(pending) > 0 {
+				m.output <- processLine{text: pending, stderr: stderr, partial: true}
+				pending = ""
+			}
+		}
+	}
+}
+
+func (m ProcessModel) waitForOutput() tea.Msg {
+	line, ok := <-m.output
+	if !ok {
+		return m.wait()
+	}
+	lines := []processLine{line}
+	// Send any other lines that are already available in the same message to avoid re-rendering for every line
+	for len(lines) < maxLinesPerUpdate {
+		select {
+		case line, ok := <-m.output:
+			if !ok {
+				return processOutputMsg{id: m.id, lines: lines}
+			}
+			lines = append(lines, line)
+		default:
+			return processOutputMsg{id: m.id, lines: lines}
+		}
+	}
+	return processOutputMsg{id: m.id, lines: lines}
+}
+
+func (m ProcessModel) wait() tea.Msg {
+	err := m.cmd.Wait()
+	exitErr := &exec.ExitError{}
+	if errors.As(err, &exitErr) {
+		return processExitMsg{id: m.id, exitCode: exitErr.ExitCode(), status: exitErr.ProcessState.String()}
+	}
+	return processExitMsg{id: m.id, err: err}
+}
+
+func (m ProcessModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case processOutputMsg:
+		if msg.id == m.id {
+			m.appendLines(msg.lines)
+			return m, m.waitForOutput
+		}
+	case processExitMsg:
+		if msg.id == m.id {
+			m.finished = true
+			m.exitCode = msg.exitCode
+			m.status = msg.status
+			if msg.err != nil {
+				return m, func() tea.Msg { return ErrorMsg(msg.err) }
+			}
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+func (m *ProcessModel) appendLines(lines []processLine) {
+	for _, line := range lines {
+		// Finish the previous line if it was shown before the command wrote the rest of it
+		if last := len(m.lines) - 1; last >= 0 && m.lines[last].partial && m.lines[last].stderr == line.stderr {
+			m.lines[last].text += line.text
+			m.lines[last].partial = line.partial
+			continue
+		}
+		m.lines = append(m.lines, line)
+	}
+}
+
+// Interrupt is part of the [Interruptible] interface.
+// It sends an interrupt signal to the command and its child processes.
+// The command is killed on platforms that don't support signals.
+func (m ProcessModel) Interrupt() tea.Cmd {
+	return func() tea.Msg {
+		if m.cmd.Process == nil {
+			return nil
+		}
+		if err := interruptProcess(m.cmd); err != nil {
+			_ = m.cmd.Process.Kill()
+		}
+		return nil
+	}
+}
+
+// Stop is part of the [Stoppable] interface.
+// It sends a stop signal (SIGTSTP) to the command and its child processes.
+// The command keeps running on platforms that don't support signals.
+func (m ProcessModel) Stop() tea.Cmd {
+	return func() tea.Msg {
+		if m.cmd.Process != nil {
+			_ = stopProcess(m.cmd)
+		}
+		return nil
+	}
+}
+
+// Continue is part of the [Stoppable] interface.
+// It resumes the command and its child processes with SIGCONT.
+func (m ProcessModel) Continue() tea.Cmd {
+	return func() tea.Msg {
+		if m.cmd.Process != nil {
+			_ = continueProcess(m.cmd)
+		}
+		return nil
+	}
+}
+
+// Finished returns whether the command has exited.
+func (m ProcessModel) Finished() bool {
+	return m.finished
+}
+
+// ExitCode returns the command's exit code once it has finished.
+// It returns -1 if the command was terminated by a signal.
+func (m ProcessModel) ExitCode() int {
+	return m.exitCode
+}
+
+func (m ProcessModel) View() string {
+	view := strings.Builder{}
+	for _, line := range m.lines {
+		if line.stderr {
+			view.WriteString(m.StderrStyle.Render(line.text))
+		} else {
+			view.WriteString(line.text)
+		}
+		view.WriteString("\n")
+	}
+	if m.finished && m.exitCode != 0 {
+		view.WriteString(m.ExitCodeStyle.Render(m.status))
+	}
+	return internal.AddNewlineIfMissing(view.String())
+}
@@ -0,0 +1,53 @@
+package executor
+
+import (
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestProcessModelPartialLine(t *testing.T) {
+	reader, writer := io.Pipe()
+	model := NewProcessModel(nil)
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go model.readLines(reader, false, &wg)
+
+	receive := func() processLine {
+		t.Helper()
+		select {
+		case line := <-model.output:
+			return line
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for output")
+			return processLine{}
+		}
+	}
+
+	_, _ = writer.Write([]byte("first\nname: "))
+	if line := receive(); line.text != "first" || line.partial {
+		t.Errorf("line = %+v, want complete line %q", line, "first")
+	}
+	partial := receive()
+	if partial.text != "name: " || !partial.partial {
+		t.Errorf("line = %+v, want partial line %q", partial, "name: ")
+	}
+	_, _ = writer.Write([]byte("bob\r\n"))
+	rest := receive()
+	_, _ = writer.Write([]byte("last"))
+	_ = writer.Close()
+	last := receive()
+	wg.Wait()
+
+	model.appendLines([]processLine{{text: "first"}, partial, rest, last})
+	want := []string{"first", "name: bob", "last"}
+	if len(model.lines) != len(want) {
+		t.Fatalf("lines = %+v, want %q", model.lines, want)
+	}
+	for i, line := range model.lines {
+		if line.text != want[i] || line.partial {
+			t.Errorf("line %d = %+v, want %q", i, line, want[i])
+		}
+	}
+}
@@ -0,0 +1,26 @@
+//go:build !unix
+
+package executor
+
+import (
+	"errors"
+	"os/exec"
+)
+
+var errSignalsNotSupported = errors.New("signals are not supported on this platform")
+
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// Interrupt signals aren't supported on this platform so the process is killed instead.
+func interruptProcess(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
+
+// Processes can't be stopped on this platform so they keep running in the background.
+func stopProcess(cmd *exec.Cmd) error {
+	return errSignalsNotSupported
+}
+
+func continueProcess(cmd *exec.Cmd) error {
+	return errSignalsNotSupported
+}
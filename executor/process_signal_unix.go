@@ -0,0 +1,36 @@
+//go:build unix
+
+package executor
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// Run the command in its own process group so the interrupt reaches any child processes
+// the same way it would in a shell.
+func setProcessGroup(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+}
+
+func interruptProcess(cmd *exec.Cmd) error {
+	return signalProcess(cmd, syscall.SIGINT)
+}
+
+func stopProcess(cmd *exec.Cmd) error {
+	return signalProcess(cmd, syscall.SIGTSTP)
+}
+
+func continueProcess(cmd *exec.Cmd) error {
+	return signalProcess(cmd, syscall.SIGCONT)
+}
+
+func signalProcess(cmd *exec.Cmd, signal syscall.Signal) error {
+	if cmd.SysProcAttr != nil && cmd.SysProcAttr.Setpgid {
+		return syscall.Kill(-cmd.Process.Pid, signal)
+	}
+	return cmd.Process.Signal(signal)
+}
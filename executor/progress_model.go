@@ -0,0 +1,196 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/aschey/bubbleprompt/internal"
+	"github.com/charmbracelet/bubbles/progress"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var lastProgressID atomic.Int64
+
+// errProgressInterrupted is the cause of the work function's cancellation when the model is interrupted.
+var errProgressInterrupted = errors.New("interrupted")
+
+const maxProgressWidth = 80
+
+type progressUpdate struct {
+	percent *float64
+	status  *string
+	log     *string
+	// The remaining fields are set once the work function returns
+	done   bool
+	output string
+	err    error
+}
+
+type progressUpdateMsg struct {
+	id      int64
+	updates []progressUpdate
+}
+
+// ProgressReporter is passed to the work function of a [ProgressModel] to publish its progress.
+// It's safe to use from multiple goroutines.
+type ProgressReporter struct {
+	ctx     context.Context
+	updates chan<- progressUpdate
+}
+
+func (r ProgressReporter) send(update progressUpdate) {
+	select {
+	case r.updates <- update:
+	case <-r.ctx.Done():
+	}
+}
+
+// SetPercent sets the progress bar to a value between 0 and 1.
+func (r ProgressReporter) SetPercent(percent float64) {
+	r.send(progressUpdate{percent: &percent})
+}
+
+// SetStatus sets the text that's shown next to the progress bar.
+func (r ProgressReporter) SetStatus(status string) {
+	r.send(progressUpdate{status: &status})
+}
+
+// Log adds a line of output above the progress bar.
+func (r ProgressReporter) Log(line string) {
+	r.send(progressUpdate{log: &line})
+}
+
+// Logf adds a formatted line of output above the progress bar.
+func (r ProgressReporter) Logf(format string, args ...any) {
+	r.Log(fmt.Sprintf(format, args...))
+}
+
+// ProgressModel runs a long-running work function in the background and shows its progress with a progress bar.
+// The work function's context is cancelled when the model is interrupted.
+// The string returned by the work function is shown after the log lines once it finishes.
+// If the work function returns [context.Canceled] after the model is interrupted,
+// the model finishes normally instead of showing the error.
+type ProgressModel struct {
+	work     func(ctx context.Context, reporter ProgressReporter) (string, error)
+	id       int64
+	ctx      context.Context
+	cancel   context.CancelCauseFunc
+	updates  chan progressUpdate
+	progress progress.Model
+	percent  float64
+	status   string
+	logs     []string
+	output   *string
+	// StatusStyle handles styling for the status text.
+	StatusStyle lipgloss.Style
+}
+
+// NewProgressModel creates a model that calls the work function when it's initialized.
+func NewProgressModel(work func(ctx context.Context, reporter ProgressReporter) (string, error)) ProgressModel {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	return ProgressModel{
+		work:        work,
+		id:          lastProgressID.Add(1),
+		ctx:         ctx,
+		cancel:      cancel,
+		updates:     make(chan progressUpdate, maxLinesPerUpdate),
+		progress:    progress.New(progress.WithDefaultGradient()),
+		StatusStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("245")),
+	}
+}
+
+// SetProgress replaces the progress bar model to customize its appearance.
+func (m *ProgressModel) SetProgress(progress progress.Model) {
+	m.progress = progress
+}
+
+func (m ProgressModel) Init() tea.Cmd {
+	return func() tea.Msg {
+		go func() {
+			// Unblock any reporters that are still running once the work is finished
+			defer m.cancel(nil)
+			output, err := m.work(m.ctx, ProgressReporter{ctx: m.ctx, updates: m.updates})
+			m.updates <- progressUpdate{done: true, output: output, err: err}
+		}()
+		return m.waitForUpdate()
+	}
+}
+
+func (m ProgressModel) waitForUpdate() tea.Msg {
+	updates := []progressUpdate{<-m.updates}
+	// Apply any other updates that are already available at the same time to avoid re-rendering for each one
+	for len(updates) < maxLinesPerUpdate && !updates[len(updates)-1].done {
+		select {
+		case update := <-m.updates:
+			updates = append(updates, update)
+		default:
+			return progressUpdateMsg{id: m.id, updates: updates}
+		}
+	}
+	return progressUpdateMsg{id: m.id, updates: updates}
+}
+
+func (m ProgressModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		// Leave room for the status text
+		m.progress.Width = min(msg.Width/2, maxProgressWidth)
+	case progressUpdateMsg:
+		if msg.id != m.id {
+			return m, nil
+		}
+		for _, update := range msg.updates {
+			if update.percent != nil {
+				m.percent = *update.percent
+			}
+			if update.status != nil {
+				m.status = *update.status
+			}
+			if update.log != nil {
+				m.logs = append(m.logs, *update.log)
+			}
+			if update.done {
+				m.output = &update.output
+				if update.err != nil && !m.interrupted(update.err) {
+					err := update.err
+					return m, func() tea.Msg { return ErrorMsg(err) }
+				}
+				return m, tea.Quit
+			}
+		}
+		return m, m.waitForUpdate
+	}
+	return m, nil
+}
+
+// interrupted returns whether the error came from the work function stopping after the model was interrupted.
+func (m ProgressModel) interrupted(err error) bool {
+	return errors.Is(err, context.Canceled) && errors.Is(context.Cause(m.ctx), errProgressInterrupted)
+}
+
+// Interrupt is part of the [Interruptible] interface.
+// It cancels the context that was passed to the work function.
+func (m ProgressModel) Interrupt() tea.Cmd {
+	m.cancel(errProgressInterrupted)
+	return nil
+}
+
+func (m ProgressModel) View() string {
+	view := strings.Builder{}
+	for _, line := range m.logs {
+		view.WriteString(line + "\n")
+	}
+	if m.output != nil {
+		view.WriteString(*m.output)
+		return internal.AddNewlineIfMissing(view.String())
+	}
+	view.WriteString(m.progress.ViewAs(m.percent))
+	if m.status != "" {
+		view.WriteString(" " + m.StatusStyle.Render(m.status))
+	}
+	return internal.AddNewlineIfMissing(view.String())
+}
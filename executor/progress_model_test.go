@@ -0,0 +1,96 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// finishProgress runs the model's commands until it quits or fails.
+func finishProgress(t *testing.T, model tea.Model, cmd tea.Cmd) (tea.Model, tea.Msg) {
+	t.Helper()
+	for cmd != nil {
+		msg := make(chan tea.Msg, 1)
+		go func() { msg <- cmd() }()
+		select {
+		case msg := <-msg:
+			switch msg := msg.(type) {
+			case tea.QuitMsg, ErrorMsg:
+				return model, msg
+			}
+			model, cmd = model.Update(msg)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for progress")
+		}
+	}
+	return model, nil
+}
+
+func TestProgressModelStartsInCmd(t *testing.T) {
+	started := make(chan struct{}, 1)
+	model := NewProgressModel(func(ctx context.Context, reporter ProgressReporter) (string, error) {
+		started <- struct{}{}
+		return "done", nil
+	})
+	cmd := model.Init()
+	select {
+	case <-started:
+		t.Fatal("work started before the command ran")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	final, msg := finishProgress(t, model, cmd)
+	if _, ok := msg.(tea.QuitMsg); !ok {
+		t.Errorf("msg = %#v, want quit", msg)
+	}
+	if view := final.View(); view != "done\n" {
+		t.Errorf("view = %q, want %q", view, "done\n")
+	}
+}
+
+func TestProgressModelInterrupt(t *testing.T) {
+	tests := []struct {
+		name      string
+		interrupt bool
+		err       error
+		want      error
+	}{
+		{name: "interrupted", interrupt: true, err: context.Canceled},
+		{name: "interrupted with wrapped error", interrupt: true, err: fmt.Errorf("migration: %w", context.Canceled)},
+		{name: "cancelled without interrupt", err: context.Canceled, want: context.Canceled},
+		{name: "other error", interrupt: true, err: errors.New("boom"), want: errors.New("boom")},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			model := NewProgressModel(func(ctx context.Context, reporter ProgressReporter) (string, error) {
+				if test.interrupt {
+					<-ctx.Done()
+				}
+				return "stopped", test.err
+			})
+			cmd := model.Init()
+			if test.interrupt {
+				model.Interrupt()
+			}
+
+			final, msg := finishProgress(t, model, cmd)
+			switch msg := msg.(type) {
+			case ErrorMsg:
+				if test.want == nil || msg.Error() != test.want.Error() {
+					t.Errorf("error = %v, want %v", error(msg), test.want)
+				}
+			case tea.QuitMsg:
+				if test.want != nil {
+					t.Errorf("quit without error, want %v", test.want)
+				}
+				if view := final.View(); view != "stopped\n" {
+					t.Errorf("view = %q, want %q", view, "stopped\n")
+				}
+			}
+		})
+	}
+}
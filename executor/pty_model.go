@@ -0,0 +1,186 @@
+package executor
+
+import (
+	"errors"
+	"os/exec"
+	"sync/atomic"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var lastPtyID atomic.Int64
+
+// ErrPtyNotSupported is returned by a [PtyModel] on platforms that don't support pseudo-terminals.
+var ErrPtyNotSupported = errors.New("pseudo-terminals are not supported on this platform")
+
+// Escape sequences for keys that don't map to a single control character
+var ptyKeySequences = map[tea.KeyType]string{
+	tea.KeyUp:       "\x1b[A",
+	tea.KeyDown:     "\x1b[B",
+	tea.KeyRight:    "\x1b[C",
+	tea.KeyLeft:     "\x1b[D",
+	tea.KeyHome:     "\x1b[H",
+	tea.KeyEnd:      "\x1b[F",
+	tea.KeyShiftTab: "\x1b[Z",
+	tea.KeyInsert:   "\x1b[2~",
+	tea.KeyDelete:   "\x1b[3~",
+	tea.KeyPgUp:     "\x1b[5~",
+	tea.KeyPgDown:   "\x1b[6~",
+	tea.KeySpace:    " ",
+}
+
+// ptyInput converts a key message to the bytes a terminal would send for it.
+func ptyInput(msg tea.KeyMsg) []byte {
+	input := ""
+	switch {
+	case msg.Type == tea.KeyRunes:
+		input = string(msg.Runes)
+	case msg.Type >= 0:
+		// Control characters are represented by their ASCII value
+		input = string(rune(msg.Type))
+	default:
+		input = ptyKeySequences[msg.Type]
+	}
+	if input != "" && msg.Alt {
+		input = "\x1b" + input
+	}
+	return []byte(input)
+}
+
+type ptyOutputMsg struct {
+	id int64
+}
+
+type ptyExitMsg struct {
+	id       int64
+	exitCode int
+	status   string
+	err      error
+}
+
+// PtyModel runs an interactive command inside a pseudo-terminal and renders the terminal's screen
+// inside the prompt while the command is running.
+// Key presses are forwarded to the command, so this works for programs that read from the terminal
+// such as password prompts and interactive interpreters.
+// The final screen is kept in the prompt's history once the command exits.
+// Colors and text attributes aren't preserved.
+// Pseudo-terminals are only supported on Linux. On other platforms, the model fails with [ErrPtyNotSupported].
+// Commands that take over the whole screen should use [tea.ExecProcess] instead.
+type PtyModel struct {
+	cmd      *exec.Cmd
+	id       int64
+	session  *ptySession
+	finished bool
+	exitCode int
+	status   string
+	// CursorStyle handles styling for the terminal's cursor.
+	CursorStyle lipgloss.Style
+	// ExitCodeStyle handles styling for the message that's shown when the command fails.
+	ExitCodeStyle lipgloss.Style
+}
+
+// NewPtyModel creates a model that runs the command in a pseudo-terminal when it's initialized.
+// The command's stdin, stdout, and stderr must not be set.
+func NewPtyModel(cmd *exec.Cmd) PtyModel {
+	return PtyModel{
+		cmd:           cmd,
+		id:            lastPtyID.Add(1),
+		session:       newPtySession(),
+		CursorStyle:   lipgloss.NewStyle().Reverse(true),
+		ExitCodeStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("9")),
+	}
+}
+
+func (m PtyModel) Init() tea.Cmd {
+	return func() tea.Msg {
+		if err := m.session.start(m.cmd); err != nil {
+			return ErrorMsg(err)
+		}
+		return m.waitForOutput()
+	}
+}
+
+func (m PtyModel) waitForOutput() tea.Msg {
+	if _, ok := <-m.session.output; !ok {
+		return m.wait()
+	}
+	return ptyOutputMsg{id: m.id}
+}
+
+func (m PtyModel) wait() tea.Msg {
+	exitCode, status, err := m.session.wait(m.cmd)
+	return ptyExitMsg{id: m.id, exitCode: exitCode, status: status, err: err}
+}
+
+func (m PtyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if !m.finished {
+			m.session.write(ptyInput(msg))
+		}
+	case tea.WindowSizeMsg:
+		m.session.resize(msg.Width, msg.Height)
+	case ptyOutputMsg:
+		if msg.id == m.id {
+			return m, m.waitForOutput
+		}
+	case ptyExitMsg:
+		if msg.id == m.id {
+			m.finished = true
+			m.exitCode = msg.exitCode
+			m.status = msg.status
+			if msg.err != nil {
+				return m, func() tea.Msg { return ErrorMsg(msg.err) }
+			}
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+// Interrupt is part of the [Interruptible] interface.
+// It sends ctrl+c to the terminal so the command can handle it the same way it would in a shell.
+func (m PtyModel) Interrupt() tea.Cmd {
+	return func() tea.Msg {
+		m.session.write([]byte{0x03})
+		return nil
+	}
+}
+
+// Stop is part of the [Stoppable] interface.
+// It stops the command and any other processes that are running in the terminal.
+func (m PtyModel) Stop() tea.Cmd {
+	return func() tea.Msg {
+		m.session.stop()
+		return nil
+	}
+}
+
+// Continue is part of the [Stoppable] interface.
+// It resumes the processes that are running in the terminal.
+func (m PtyModel) Continue() tea.Cmd {
+	return func() tea.Msg {
+		m.session.resume()
+		return nil
+	}
+}
+
+// Finished returns whether the command has exited.
+func (m PtyModel) Finished() bool {
+	return m.finished
+}
+
+// ExitCode returns the command's exit code once it has finished.
+// It returns -1 if the command was terminated by a signal.
+func (m PtyModel) ExitCode() int {
+	return m.exitCode
+}
+
+func (m PtyModel) View() string {
+	view := m.session.view(m.CursorStyle, !m.finished)
+	if m.finished && m.exitCode != 0 {
+		view += m.ExitCodeStyle.Render(m.status) + "\n"
+	}
+	return view
+}
@@ -0,0 +1,159 @@
+//go:build linux
+
+package executor
+
+import (
+	"io"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ActiveState/vt10x"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestPtyInput(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  tea.KeyMsg
+		want string
+	}{
+		{name: "runes", msg: tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("hé")}, want: "hé"},
+		{name: "alt runes", msg: tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b"), Alt: true}, want: "\x1bb"},
+		{name: "enter", msg: tea.KeyMsg{Type: tea.KeyEnter}, want: "\r"},
+		{name: "ctrl+c", msg: tea.KeyMsg{Type: tea.KeyCtrlC}, want: "\x03"},
+		{name: "ctrl+d", msg: tea.KeyMsg{Type: tea.KeyCtrlD}, want: "\x04"},
+		{name: "backspace", msg: tea.KeyMsg{Type: tea.KeyBackspace}, want: "\x7f"},
+		{name: "tab", msg: tea.KeyMsg{Type: tea.KeyTab}, want: "\t"},
+		{name: "escape", msg: tea.KeyMsg{Type: tea.KeyEscape}, want: "\x1b"},
+		{name: "space", msg: tea.KeyMsg{Type: tea.KeySpace}, want: " "},
+		{name: "up", msg: tea.KeyMsg{Type: tea.KeyUp}, want: "\x1b[A"},
+		{name: "alt+left", msg: tea.KeyMsg{Type: tea.KeyLeft, Alt: true}, want: "\x1b\x1b[D"},
+		{name: "delete", msg: tea.KeyMsg{Type: tea.KeyDelete}, want: "\x1b[3~"},
+		{name: "shift+tab", msg: tea.KeyMsg{Type: tea.KeyShiftTab}, want: "\x1b[Z"},
+		{name: "unsupported", msg: tea.KeyMsg{Type: tea.KeyF1}, want: ""},
+		{name: "alt unsupported", msg: tea.KeyMsg{Type: tea.KeyF1, Alt: true}, want: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := string(ptyInput(test.msg)); got != test.want {
+				t.Errorf("input = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+// testPtySession creates a session whose screen shows the output without running a command.
+func testPtySession(t *testing.T, output string) *ptySession {
+	t.Helper()
+	state := &vt10x.State{RecordHistory: true}
+	terminal, err := vt10x.New(state, strings.NewReader(output), io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	terminal.Resize(20, 5)
+	for terminal.Parse() == nil {
+	}
+	session := newPtySession()
+	session.state = state
+	return session
+}
+
+func TestPtySessionView(t *testing.T) {
+	cursorStyle := lipgloss.NewStyle().Transform(func(s string) string { return "[" + s + "]" })
+	tests := []struct {
+		name       string
+		output     string
+		showCursor bool
+		want       string
+	}{
+		{name: "empty", output: "", want: ""},
+		{name: "trailing blanks", output: "ab  \r\ncd  ", want: "ab\ncd\n"},
+		{name: "blank rows", output: "ab\r\n\r\ncd\r\n", want: "ab\n\ncd\n"},
+		{name: "cursor after text", output: "ab\r\ncd", showCursor: true, want: "ab\ncd[ ]\n"},
+		{name: "cursor inside text", output: "abc\x1b[2D", showCursor: true, want: "a[b]c\n"},
+		{name: "cursor past trailing blanks", output: "ab  ", showCursor: true, want: "ab  [ ]\n"},
+		{name: "cursor on empty row", output: "ab\r\n", showCursor: true, want: "ab\n[ ]\n"},
+		{name: "hidden cursor", output: "\x1b[?25lab", showCursor: true, want: "ab\n"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			session := testPtySession(t, test.output)
+			if got := session.view(cursorStyle, test.showCursor); got != test.want {
+				t.Errorf("view = %q, want %q", got, test.want)
+			}
+		})
+	}
+
+	if got := newPtySession().view(cursorStyle, true); got != "" {
+		t.Errorf("view before starting = %q, want empty", got)
+	}
+}
+
+// runPtyModel runs the model until it finishes and returns the final model and the message that ended it.
+func runPtyModel(t *testing.T, model PtyModel) (PtyModel, tea.Msg) {
+	t.Helper()
+	cmd := model.Init()
+	for cmd != nil {
+		result := make(chan tea.Msg, 1)
+		go func() { result <- cmd() }()
+		var msg tea.Msg
+		select {
+		case msg = <-result:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for the command")
+		}
+		switch msg.(type) {
+		case tea.QuitMsg, ErrorMsg:
+			return model, msg
+		}
+		var updated tea.Model
+		updated, cmd = model.Update(msg)
+		model = updated.(PtyModel)
+	}
+	t.Fatal("the model stopped without finishing")
+	return model, nil
+}
+
+func TestPtyModelRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		script   string
+		exitCode int
+		view     string
+	}{
+		{name: "success", script: "echo hello", view: "hello\n"},
+		{name: "failure", script: "echo oops; exit 3", exitCode: 3, view: "oops\nexit status 3\n"},
+		{name: "false", script: "false", exitCode: 1, view: "exit status 1\n"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			model := NewPtyModel(exec.Command("sh", "-c", test.script))
+			if model.Finished() {
+				t.Error("finished before starting")
+			}
+			model, msg := runPtyModel(t, model)
+			if _, ok := msg.(tea.QuitMsg); !ok {
+				t.Fatalf("final message = %#v, want quit", msg)
+			}
+			if !model.Finished() {
+				t.Error("not finished")
+			}
+			if model.ExitCode() != test.exitCode {
+				t.Errorf("exit code = %d, want %d", model.ExitCode(), test.exitCode)
+			}
+			if view := model.View(); view != test.view {
+				t.Errorf("view = %q, want %q", view, test.view)
+			}
+		})
+	}
+}
+
+func TestPtyModelStartError(t *testing.T) {
+	_, msg := runPtyModel(t, NewPtyModel(exec.Command("/nonexistent/command")))
+	if _, ok := msg.(ErrorMsg); !ok {
+		t.Errorf("final message = %#v, want an error", msg)
+	}
+}
@@ -0,0 +1,168 @@
+//go:build linux
+
+package executor
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/ActiveState/vt10x"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/creack/pty"
+)
+
+const (
+	defaultPtyCols = 80
+	defaultPtyRows = 24
+)
+
+// ptySession holds the pseudo-terminal and the emulated screen.
+// It's shared between copies of the model since the terminal is updated in the background.
+type ptySession struct {
+	mu       sync.Mutex
+	ptmx     *os.File
+	terminal *vt10x.VT
+	state    *vt10x.State
+	pid      int
+	cols     int
+	rows     int
+	output   chan struct{}
+}
+
+func newPtySession() *ptySession {
+	return &ptySession{
+		cols: defaultPtyCols,
+		rows: defaultPtyRows,
+		// Notifications are dropped while one is pending since the screen is rendered from the latest state anyway
+		output: make(chan struct{}, 1),
+	}
+}
+
+func (s *ptySession) start(cmd *exec.Cmd) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ptmx, err := pty.StartWithSize(cmd, &pty.Winsize{Cols: uint16(s.cols), Rows: uint16(s.rows)})
+	if err != nil {
+		return err
+	}
+	// Keep lines that scroll off the screen so the full output is shown
+	state := &vt10x.State{RecordHistory: true}
+	// Responses to terminal queries such as the cursor position are written back to the command
+	terminal, err := vt10x.New(state, ptmx, ptmx)
+	if err != nil {
+		_ = ptmx.Close()
+		return err
+	}
+	terminal.Resize(s.cols, s.rows)
+	s.ptmx = ptmx
+	s.terminal = terminal
+	s.state = state
+	s.pid = cmd.Process.Pid
+
+	go func() {
+		defer close(s.output)
+		// Parse returns an error once the command exits and the terminal is closed
+		for terminal.Parse() == nil {
+			select {
+			case s.output <- struct{}{}:
+			default:
+			}
+		}
+	}()
+	return nil
+}
+
+func (s *ptySession) wait(cmd *exec.Cmd) (int, string, error) {
+	err := cmd.Wait()
+	s.mu.Lock()
+	_ = s.ptmx.Close()
+	// The process ID could be reused once the command exits
+	s.pid = 0
+	s.mu.Unlock()
+	exitErr := &exec.ExitError{}
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), exitErr.ProcessState.String(), nil
+	}
+	return 0, "", err
+}
+
+func (s *ptySession) write(input []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ptmx != nil {
+		_, _ = s.ptmx.Write(input)
+	}
+}
+
+// The command is the leader of its own session, so the kernel ignores SIGTSTP for it
+// since there's no shell in that session to resume it. SIGSTOP can't be ignored.
+func (s *ptySession) stop() {
+	s.signal(syscall.SIGSTOP)
+}
+
+func (s *ptySession) resume() {
+	s.signal(syscall.SIGCONT)
+}
+
+func (s *ptySession) signal(signal syscall.Signal) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.pid > 0 {
+		// Signal the whole process group so child processes are stopped as well
+		_ = syscall.Kill(-s.pid, signal)
+	}
+}
+
+func (s *ptySession) resize(cols int, rows int) {
+	if cols <= 0 || rows <= 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cols = cols
+	s.rows = rows
+	if s.ptmx != nil {
+		_ = pty.Setsize(s.ptmx, &pty.Winsize{Cols: uint16(cols), Rows: uint16(rows)})
+		s.terminal.Resize(cols, rows)
+	}
+}
+
+func (s *ptySession) view(cursorStyle lipgloss.Style, showCursor bool) string {
+	s.mu.Lock()
+	state := s.state
+	s.mu.Unlock()
+	if state == nil {
+		return ""
+	}
+
+	lines := strings.Split(state.String(), "\n")
+	state.Lock()
+	cursorX, cursorY := state.GlobalCursor()
+	showCursor = showCursor && state.CursorVisible()
+	state.Unlock()
+
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " ")
+	}
+	if showCursor && cursorY < len(lines) {
+		line := []rune(lines[cursorY])
+		if len(line) <= cursorX {
+			line = append(line, []rune(strings.Repeat(" ", cursorX-len(line)+1))...)
+		}
+		lines[cursorY] = string(line[:cursorX]) + cursorStyle.Render(string(line[cursorX])) + string(line[cursorX+1:])
+	} else {
+		cursorY = -1
+	}
+	// Remove the empty rows at the bottom of the screen that haven't been written to yet
+	for len(lines) > 0 && len(lines)-1 > cursorY && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
@@ -0,0 +1,37 @@
+//go:build !linux
+
+package executor
+
+import (
+	"os/exec"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+type ptySession struct {
+	output chan struct{}
+}
+
+func newPtySession() *ptySession {
+	return &ptySession{output: make(chan struct{})}
+}
+
+func (s *ptySession) start(cmd *exec.Cmd) error {
+	return ErrPtyNotSupported
+}
+
+func (s *ptySession) wait(cmd *exec.Cmd) (int, string, error) {
+	return 0, "", ErrPtyNotSupported
+}
+
+func (s *ptySession) write(input []byte) {}
+
+func (s *ptySession) stop() {}
+
+func (s *ptySession) resume() {}
+
+func (s *ptySession) resize(cols int, rows int) {}
+
+func (s *ptySession) view(cursorStyle lipgloss.Style, showCursor bool) string {
+	return ""
+}
@@ -0,0 +1,13 @@
+package executor
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// Stoppable can optionally be implemented by an executor model to pause its work when the prompt's
+// background key (ctrl+z by default) stops it, like a job that's stopped in a shell.
+// Stop is called when the executor is moved to the job table and Continue is called once the job is resumed.
+// The prompt doesn't send any messages to a stopped executor, so executor models that don't implement
+// this interface only stop handling messages while any work that they started keeps running.
+type Stoppable interface {
+	Stop() tea.Cmd
+	Continue() tea.Cmd
+}
@@ -0,0 +1,63 @@
+package executor_test
+
+import (
+	"fmt"
+
+	"github.com/aschey/bubbleprompt/executor"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func ExampleNewTableModel() {
+	type user struct {
+		Name     string
+		Email    string `table:"E-mail"`
+		password string
+	}
+	model := executor.NewTableModel([]user{
+		{Name: "Alice", Email: "alice@example.com", password: "hunter2"},
+		{Name: "Bob", Email: "bob@example.com", password: "*******"},
+	})
+	// The prompt sends the terminal size to the model after it's initialized
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 24, Height: 10})
+	fmt.Print(updated.View())
+	// Output:
+	// ╭───────┬──────────────╮
+	// │ Name  │ E-mail       │
+	// ├───────┼──────────────┤
+	// │ Alice │ alice@exampl │
+	// │       │ e.com        │
+	// │ Bob   │ bob@example. │
+	// │       │ com          │
+	// ╰───────┴──────────────╯
+}
+
+func ExampleNewTreeModel() {
+	model := executor.NewTreeModel(executor.TreeNode{
+		Label: "src",
+		Children: []executor.TreeNode{
+			{Label: "cmd", Children: []executor.TreeNode{{Label: "main.go"}}},
+			{Label: "README.md"},
+		},
+	})
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 80, Height: 10})
+	fmt.Print(updated.View())
+	// Output:
+	// src
+	// ├── cmd
+	// │   └── main.go
+	// └── README.md
+}
+
+func ExampleNewJSONModel() {
+	model := executor.NewJSONModel(map[string]any{"name": "bubbleprompt", "tags": []string{"cli", "tui"}})
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 80, Height: 10})
+	fmt.Print(updated.View())
+	// Output:
+	// {
+	//   "name": "bubbleprompt",
+	//   "tags": [
+	//     "cli",
+	//     "tui"
+	//   ]
+	// }
+}
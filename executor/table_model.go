@@ -0,0 +1,240 @@
+package executor
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+
+	"github.com/aschey/bubbleprompt/internal"
+	"github.com/aschey/bubbleprompt/suggestion"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// TableModel renders a list of records as a table.
+// Cells are wrapped so the table fits within the width of the terminal.
+type TableModel struct {
+	columns []string
+	rows    [][]string
+	err     error
+	width   int
+	// Formatter handles styling for the table.
+	Formatter suggestion.OutputFormatter
+	// Border sets the characters that are used to draw the table's borders.
+	Border lipgloss.Border
+}
+
+// NewTableModel creates a model that renders the records as a table.
+// Records can be maps with string keys or structs.
+// Map keys are sorted to determine the column order.
+// Struct columns use the order of the exported fields and the name of each column
+// can be changed with a `table` struct tag. Fields with the tag `table:"-"` are skipped.
+func NewTableModel[T any](records []T) TableModel {
+	model := TableModel{
+		Formatter: suggestion.DefaultFormatters().Output,
+		Border:    lipgloss.RoundedBorder(),
+	}
+	model.columns, model.rows, model.err = tableData(records)
+	return model
+}
+
+// SetColumns changes which columns are shown and the order they're shown in.
+func (m *TableModel) SetColumns(columns ...string) {
+	rows := make([][]string, len(m.rows))
+	for i, row := range m.rows {
+		for _, column := range columns {
+			cell := ""
+			if index := slices.Index(m.columns, column); index > -1 {
+				cell = row[index]
+			}
+			rows[i] = append(rows[i], cell)
+		}
+	}
+	m.columns = columns
+	m.rows = rows
+}
+
+func tableData[T any](records []T) ([]string, [][]string, error) {
+	values := []reflect.Value{}
+	for _, record := range records {
+		if value := indirectValue(reflect.ValueOf(record)); value.IsValid() {
+			values = append(values, value)
+		}
+	}
+	if len(values) == 0 {
+		return nil, nil, nil
+	}
+	recordType := values[0].Type()
+	for _, value := range values {
+		if value.Type() != recordType {
+			return nil, nil, fmt.Errorf("table records must all be the same type, got %s and %s", recordType, value.Type())
+		}
+	}
+
+	switch {
+	case recordType.Kind() == reflect.Map && recordType.Key().Kind() == reflect.String:
+		columns, rows := mapTableData(values)
+		return columns, rows, nil
+	case recordType.Kind() == reflect.Struct:
+		columns, rows := structTableData(values)
+		return columns, rows, nil
+	}
+	return nil, nil, fmt.Errorf("table records must be maps with string keys or structs, got %s", recordType)
+}
+
+func mapTableData(records []reflect.Value) ([]string, [][]string) {
+	columns := []string{}
+	for _, record := range records {
+		for _, key := range record.MapKeys() {
+			if !slices.Contains(columns, key.String()) {
+				columns = append(columns, key.String())
+			}
+		}
+	}
+	sort.Strings(columns)
+
+	rows := [][]string{}
+	for _, record := range records {
+		row := []string{}
+		for _, column := range columns {
+			key := reflect.ValueOf(column).Convert(record.Type().Key())
+			row = append(row, formatCell(record.MapIndex(key)))
+		}
+		rows = append(rows, row)
+	}
+	return columns, rows
+}
+
+func structTableData(records []reflect.Value) ([]string, [][]string) {
+	recordType := records[0].Type()
+	columns := []string{}
+	fields := []int{}
+	for i := 0; i < recordType.NumField(); i++ {
+		field := recordType.Field(i)
+		name := field.Tag.Get("table")
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		columns = append(columns, name)
+		fields = append(fields, i)
+	}
+
+	rows := [][]string{}
+	for _, record := range records {
+		row := []string{}
+		for _, field := range fields {
+			row = append(row, formatCell(record.Field(field)))
+		}
+		rows = append(rows, row)
+	}
+	return columns, rows
+}
+
+// indirectValue follows pointers and interfaces to the underlying value.
+// It returns an invalid value if any of them are nil.
+func indirectValue(value reflect.Value) reflect.Value {
+	for value.IsValid() && (value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface) {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		value = value.Elem()
+	}
+	return value
+}
+
+func formatCell(value reflect.Value) string {
+	value = indirectValue(value)
+	if !value.IsValid() {
+		return ""
+	}
+	return fmt.Sprint(value.Interface())
+}
+
+func (m TableModel) Init() tea.Cmd {
+	if m.err != nil {
+		return func() tea.Msg { return ErrorMsg(m.err) }
+	}
+	return nil
+}
+
+func (m TableModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// The prompt sends the terminal size after the model is initialized,
+	// so the table can be rendered once the width is known
+	if msg, ok := msg.(tea.WindowSizeMsg); ok {
+		m.width = msg.Width
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// columnWidths returns the width of each column's content after shrinking the widest columns
+// until the table fits in the terminal.
+func (m TableModel) columnWidths() []int {
+	widths := make([]int, len(m.columns))
+	for i, column := range m.columns {
+		widths[i] = lipgloss.Width(column)
+		for _, row := range m.rows {
+			widths[i] = max(widths[i], lipgloss.Width(row[i]))
+		}
+	}
+	if m.width <= 0 {
+		return widths
+	}
+
+	padding := max(m.Formatter.TableHeader.GetHorizontalFrameSize(), m.Formatter.TableCell.GetHorizontalFrameSize())
+	// Each column has a border on its left side plus one more on the right side of the table
+	available := m.width - len(widths)*(padding+1) - 1
+	total := 0
+	for _, width := range widths {
+		total += width
+	}
+	for total > available {
+		widest := 0
+		for i, width := range widths {
+			if width > widths[widest] {
+				widest = i
+			}
+		}
+		if widths[widest] <= 1 {
+			break
+		}
+		widths[widest]--
+		total--
+	}
+	return widths
+}
+
+func (m TableModel) View() string {
+	if len(m.columns) == 0 {
+		return ""
+	}
+	widths := m.columnWidths()
+	wrapRow := func(row []string) []string {
+		wrapped := make([]string, len(row))
+		for i, cell := range row {
+			wrapped[i] = ansi.Wrap(cell, widths[i], "")
+		}
+		return wrapped
+	}
+
+	t := table.New().
+		Border(m.Border).
+		BorderStyle(m.Formatter.TableBorder).
+		Headers(wrapRow(m.columns)...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return m.Formatter.TableHeader
+			}
+			return m.Formatter.TableCell
+		})
+	for _, row := range m.rows {
+		t.Row(wrapRow(row)...)
+	}
+	return internal.AddNewlineIfMissing(t.Render())
+}
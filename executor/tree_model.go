@@ -0,0 +1,85 @@
+package executor
+
+import (
+	"strings"
+
+	"github.com/aschey/bubbleprompt/internal"
+	"github.com/aschey/bubbleprompt/suggestion"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// TreeNode is a node in a tree that's rendered by a [TreeModel].
+type TreeNode struct {
+	// Label is the text that's shown for the node.
+	Label string
+	// Children are the nodes that are shown below this node.
+	Children []TreeNode
+}
+
+// TreeModel renders a tree of nodes with lines connecting each node to its parent.
+// Labels are wrapped so the tree fits within the width of the terminal.
+type TreeModel struct {
+	roots []TreeNode
+	width int
+	// Formatter handles styling for the tree.
+	Formatter suggestion.OutputFormatter
+}
+
+// NewTreeModel creates a model that renders the nodes as a tree.
+// Root nodes are shown without any indentation.
+func NewTreeModel(roots ...TreeNode) TreeModel {
+	return TreeModel{roots: roots, Formatter: suggestion.DefaultFormatters().Output}
+}
+
+func (m TreeModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m TreeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// The prompt sends the terminal size after the model is initialized,
+	// so the tree can be rendered once the width is known
+	if msg, ok := msg.(tea.WindowSizeMsg); ok {
+		m.width = msg.Width
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m TreeModel) View() string {
+	lines := []string{}
+	for _, root := range m.roots {
+		m.renderNode(root, "", "", "", &lines)
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return internal.AddNewlineIfMissing(strings.Join(lines, "\n"))
+}
+
+// renderNode adds the lines for the node and its children.
+// The branch is shown before the first line of the label and the indent is shown before the rest of the lines.
+func (m TreeModel) renderNode(node TreeNode, indent string, branch string, labelIndent string, lines *[]string) {
+	label := node.Label
+	prefixWidth := lipgloss.Width(indent + branch)
+	if m.width > 0 && m.width > prefixWidth {
+		label = ansi.Wrap(label, m.width-prefixWidth, "")
+	}
+	for i, line := range strings.Split(label, "\n") {
+		prefix := indent + branch
+		if i > 0 {
+			prefix = indent + labelIndent
+		}
+		*lines = append(*lines, m.Formatter.TreeBranch.Render(prefix)+m.Formatter.TreeLabel.Render(line))
+	}
+
+	childIndent := indent + labelIndent
+	for i, child := range node.Children {
+		if i == len(node.Children)-1 {
+			m.renderNode(child, childIndent, "└── ", "    ", lines)
+		} else {
+			m.renderNode(child, childIndent, "├── ", "│   ", lines)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package history
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// FileHistory is a [History] that persists entries to a file so they're available across sessions.
+// Each entry is stored on its own line. Entries that span multiple lines are stored as quoted strings.
+type FileHistory struct {
+	memory *MemoryHistory
+	path   string
+}
+
+// NewFileHistory creates a history backed by the file at the given path.
+// Any entries already present in the file are loaded.
+// The file and its parent directories are created when the first entry is added.
+func NewFileHistory(path string, options ...Option) (*FileHistory, error) {
+	history := &FileHistory{
+		memory: NewMemoryHistory(options...),
+		path:   path,
+	}
+	if err := history.load(); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+func (h *FileHistory) load() error {
+	file, err := os.Open(h.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// Use a reader instead of a scanner since scanners can't read lines that are longer than their buffer
+	reader := bufio.NewReader(file)
+	removed := false
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+		_, entryRemoved := h.memory.add(decodeEntry(line))
+		removed = removed || entryRemoved
+		if err != nil {
+			break
+		}
+	}
+	if removed {
+		// Compact the file if it contained duplicates or too many entries
+		return h.save()
+	}
+	return nil
+}
+
+// Add appends an entry to the history and writes it to the file. Blank entries are ignored.
+func (h *FileHistory) Add(entry string) error {
+	appended, removed := h.memory.add(entry)
+	if !appended {
+		return nil
+	}
+	if removed {
+		// Existing entries changed so the whole file needs to be rewritten
+		return h.save()
+	}
+	return h.appendEntry(entry)
+}
+
+// Entries returns all stored entries, ordered from oldest to newest.
+func (h *FileHistory) Entries() []string {
+	return h.memory.Entries()
+}
+
+// Len returns the number of stored entries.
+func (h *FileHistory) Len() int {
+	return h.memory.Len()
+}
+
+// Clear removes all entries from the history and truncates the file.
+func (h *FileHistory) Clear() error {
+	_ = h.memory.Clear()
+	return h.save()
+}
+
+// Path returns the path of the backing file.
+func (h *FileHistory) Path() string {
+	return h.path
+}
+
+func (h *FileHistory) appendEntry(entry string) error {
+	if err := h.ensureDir(); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.WriteString(encodeEntry(entry) + "\n"); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func (h *FileHistory) save() error {
+	if err := h.ensureDir(); err != nil {
+		return err
+	}
+	var builder strings.Builder
+	for _, entry := range h.memory.entries {
+		builder.WriteString(encodeEntry(entry) + "\n")
+	}
+	// Write to a temp file first so the history isn't lost if the write fails
+	tempPath := h.path + ".tmp"
+	if err := os.WriteFile(tempPath, []byte(builder.String()), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tempPath, h.path)
+}
+
+func (h *FileHistory) ensureDir() error {
+	return os.MkdirAll(filepath.Dir(h.path), 0o700)
+}
+
+func encodeEntry(entry string) string {
+	if strings.ContainsAny(entry, "\r\n") || strings.HasPrefix(entry, `"`) {
+		return strconv.Quote(entry)
+	}
+	return entry
+}
+
+func decodeEntry(line string) string {
+	if strings.HasPrefix(line, `"`) {
+		if entry, err := strconv.Unquote(line); err == nil {
+			return entry
+		}
+	}
+	return line
+}
@@ -0,0 +1,15 @@
+// Package history provides storage for previously submitted inputs.
+// It can be supplied to the prompt to enable shell-like recall of earlier entries.
+package history
+
+// History stores previously submitted inputs, ordered from oldest to newest.
+type History interface {
+	// Add appends an entry to the history.
+	Add(entry string) error
+	// Entries returns all stored entries, ordered from oldest to newest.
+	Entries() []string
+	// Len returns the number of stored entries.
+	Len() int
+	// Clear removes all entries from the history.
+	Clear() error
+}
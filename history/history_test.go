@@ -0,0 +1,53 @@
+package history_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aschey/bubbleprompt/history"
+)
+
+func ExampleNewMemoryHistory() {
+	h := history.NewMemoryHistory(history.WithMaxSize(3))
+	for _, entry := range []string{"first", "second", "first", "third", "fourth"} {
+		_ = h.Add(entry)
+	}
+
+	fmt.Println(h.Entries())
+	// Output: [first third fourth]
+}
+
+func ExampleNewFileHistory() {
+	dir, _ := os.MkdirTemp("", "history")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "history")
+
+	h, _ := history.NewFileHistory(path)
+	_ = h.Add("get weather")
+	_ = h.Add("set secret\nhunter2")
+
+	// Entries are loaded from the file when the history is created
+	reloaded, _ := history.NewFileHistory(path)
+	fmt.Printf("%q\n", reloaded.Entries())
+	// Output: ["get weather" "set secret\nhunter2"]
+}
+
+func ExampleNewFileHistory_longEntries() {
+	dir, _ := os.MkdirTemp("", "history")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "history")
+
+	h, _ := history.NewFileHistory(path)
+	_ = h.Add(strings.Repeat("a", 100_000))
+
+	// Entries aren't limited to a fixed line length
+	reloaded, err := history.NewFileHistory(path)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(len(reloaded.Entries()[0]))
+	// Output: 100000
+}
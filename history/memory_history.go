@@ -0,0 +1,59 @@
+package history
+
+import (
+	"slices"
+	"strings"
+)
+
+// MemoryHistory is a [History] that only keeps entries in memory.
+// Entries are lost when the program exits.
+type MemoryHistory struct {
+	entries  []string
+	settings settings
+}
+
+// NewMemoryHistory creates a new in-memory history.
+func NewMemoryHistory(options ...Option) *MemoryHistory {
+	return &MemoryHistory{settings: newSettings(options)}
+}
+
+// Add appends an entry to the history. Blank entries are ignored.
+func (h *MemoryHistory) Add(entry string) error {
+	h.add(entry)
+	return nil
+}
+
+// add appends the entry and reports whether any existing entries were removed.
+func (h *MemoryHistory) add(entry string) (appended bool, removed bool) {
+	if strings.TrimSpace(entry) == "" {
+		return false, false
+	}
+	if h.settings.dedupe {
+		if index := slices.Index(h.entries, entry); index > -1 {
+			h.entries = slices.Delete(h.entries, index, index+1)
+			removed = true
+		}
+	}
+	h.entries = append(h.entries, entry)
+	if h.settings.maxSize > 0 && len(h.entries) > h.settings.maxSize {
+		h.entries = h.entries[len(h.entries)-h.settings.maxSize:]
+		removed = true
+	}
+	return true, removed
+}
+
+// Entries returns all stored entries, ordered from oldest to newest.
+func (h *MemoryHistory) Entries() []string {
+	return slices.Clone(h.entries)
+}
+
+// Len returns the number of stored entries.
+func (h *MemoryHistory) Len() int {
+	return len(h.entries)
+}
+
+// Clear removes all entries from the history.
+func (h *MemoryHistory) Clear() error {
+	h.entries = nil
+	return nil
+}
@@ -0,0 +1,37 @@
+package history
+
+type settings struct {
+	maxSize int
+	dedupe  bool
+}
+
+type Option func(settings *settings)
+
+// WithMaxSize sets the maximum number of entries to retain.
+// The oldest entries are discarded once the limit is reached.
+// A value less than 1 disables the limit.
+func WithMaxSize(maxSize int) Option {
+	return func(settings *settings) {
+		settings.maxSize = maxSize
+	}
+}
+
+// WithDeduplication controls whether adding an entry removes any older copies of it
+// so that each entry is only stored once.
+func WithDeduplication(dedupe bool) Option {
+	return func(settings *settings) {
+		settings.dedupe = dedupe
+	}
+}
+
+func newSettings(options []Option) settings {
+	defaultMaxSize := 1000
+	settings := settings{
+		maxSize: defaultMaxSize,
+		dedupe:  true,
+	}
+	for _, option := range options {
+		option(&settings)
+	}
+	return settings
+}
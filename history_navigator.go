@@ -0,0 +1,66 @@
+package prompt
+
+import "github.com/aschey/bubbleprompt/history"
+
+// historyNavigator tracks the position in the history while the user cycles through previous entries.
+type historyNavigator struct {
+	history    history.History
+	navigating bool
+	index      int
+	// Text the user was editing before they started navigating so it can be restored
+	draft string
+}
+
+func newHistoryNavigator(history history.History) historyNavigator {
+	return historyNavigator{history: history}
+}
+
+func (n historyNavigator) enabled() bool {
+	return n.history != nil
+}
+
+func (n *historyNavigator) previous(current string) (string, bool) {
+	entries := n.history.Entries()
+	if len(entries) == 0 {
+		return "", false
+	}
+	if !n.navigating {
+		n.navigating = true
+		n.draft = current
+		n.index = len(entries)
+	}
+	if n.index == 0 {
+		// Already at the oldest entry
+		return "", false
+	}
+	n.index--
+	return entries[n.index], true
+}
+
+func (n *historyNavigator) next() (string, bool) {
+	if !n.navigating {
+		return "", false
+	}
+	entries := n.history.Entries()
+	n.index++
+	if n.index >= len(entries) {
+		// Moved past the newest entry, go back to what the user was typing
+		n.reset()
+		return n.draft, true
+	}
+	return entries[n.index], true
+}
+
+func (n *historyNavigator) reset() {
+	n.navigating = false
+	n.index = 0
+}
+
+func (n *historyNavigator) add(entry string) error {
+	n.reset()
+	n.draft = ""
+	if !n.enabled() {
+		return nil
+	}
+	return n.history.Add(entry)
+}
@@ -0,0 +1,76 @@
+package prompt
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aschey/bubbleprompt/history"
+	"github.com/aschey/bubbleprompt/suggestion"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type failingHistory struct {
+	*history.MemoryHistory
+}
+
+func (h failingHistory) Add(entry string) error {
+	return errors.New("disk full")
+}
+
+func TestHistoryRecall(t *testing.T) {
+	tests := []struct {
+		name        string
+		suggestions []suggestion.Suggestion[any]
+		typed       string
+		keys        []tea.KeyMsg
+		want        string
+	}{
+		{name: "previous entry", keys: []tea.KeyMsg{keyUp}, want: "second"},
+		{name: "oldest entry", keys: []tea.KeyMsg{keyUp, keyUp, keyUp}, want: "first"},
+		{name: "next entry", keys: []tea.KeyMsg{keyUp, keyUp, keyDown}, want: "second"},
+		{name: "restores draft", typed: "dra", keys: []tea.KeyMsg{keyUp, keyDown}, want: "dra"},
+		{
+			name:        "no recall while suggestions are shown",
+			suggestions: []suggestion.Suggestion[any]{{Text: "alpha"}, {Text: "beta"}},
+			keys:        []tea.KeyMsg{keyUp},
+			want:        "",
+		},
+		{
+			name:        "suggestions keep up",
+			suggestions: []suggestion.Suggestion[any]{{Text: "alpha"}, {Text: "beta"}},
+			keys:        []tea.KeyMsg{keyDown, keyDown, keyUp},
+			want:        "alpha",
+		},
+		{
+			name:        "recall without matching suggestions",
+			suggestions: []suggestion.Suggestion[any]{{Text: "alpha"}, {Text: "beta"}},
+			typed:       "x",
+			keys:        []tea.KeyMsg{keyUp, keyUp},
+			want:        "first",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler := &testHandler{suggestions: test.suggestions}
+			p := newTestPrompt(t, handler, WithHistory[any](history.NewMemoryHistory()))
+			p.submit("first")
+			p.submit("second")
+			p.typeText(test.typed)
+			for _, key := range test.keys {
+				p.send(key)
+			}
+			if p.value() != test.want {
+				t.Errorf("value = %q, want %q", p.value(), test.want)
+			}
+		})
+	}
+}
+
+func TestHistoryAddError(t *testing.T) {
+	p := newTestPrompt(t, &testHandler{}, WithHistory[any](failingHistory{history.NewMemoryHistory()}))
+	p.submit("first")
+	if !strings.Contains(p.output(), "failed to save history") || !strings.Contains(p.output(), "disk full") {
+		t.Errorf("output %q doesn't contain the history error", p.output())
+	}
+}
@@ -0,0 +1,182 @@
+package prompt
+
+import (
+	"github.com/aschey/bubbleprompt/completer"
+	"github.com/aschey/bubbleprompt/input"
+	"github.com/aschey/bubbleprompt/suggestion"
+	"github.com/aschey/bubbleprompt/suggestion/dropdown"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-runewidth"
+)
+
+const (
+	historySearchPrompt       = "(reverse-i-search)"
+	failedHistorySearchPrompt = "(failed reverse-i-search)"
+)
+
+// historySearchInput wraps the prompt's input so the search dropdown reads the search query
+// instead of the input text. Selecting a match shouldn't modify the input until the search is accepted.
+type historySearchInput[T any] struct {
+	input.Input[T]
+	query *[]rune
+}
+
+func (i historySearchInput[T]) Runes() []rune {
+	return *i.query
+}
+
+func (i historySearchInput[T]) CursorIndex() int {
+	return len(*i.query)
+}
+
+func (i historySearchInput[T]) SuggestionRunes(runes []rune) []rune {
+	return runes
+}
+
+func (i historySearchInput[T]) OnSuggestionChanged(suggestion suggestion.Suggestion[T]) {}
+
+func (i historySearchInput[T]) OnSuggestionUnselected() {}
+
+type historySearch[T any] struct {
+	manager        *dropdown.Model[T]
+	query          []rune
+	sequenceNumber int
+}
+
+func (m *Model[T]) startHistorySearch() {
+	search := &historySearch[T]{query: []rune{}}
+	search.manager = dropdown.New[T](historySearchInput[T]{Input: m.textInput, query: &search.query})
+	// Match the appearance of the prompt's suggestions
+	search.manager.SetMaxSuggestions(m.suggestionManager.MaxSuggestions())
+	search.manager.SetSelectionIndicator(m.suggestionManager.SelectionIndicator())
+	search.manager.SetFormatters(m.suggestionManager.Formatters())
+	if m.suggestionManager.Scrollbar() == "" {
+		search.manager.DisableScrollbar()
+	}
+	search.manager.SetKeyMap(m.keyMap.Suggestion)
+	search.manager.SetShowSuggestions(true)
+
+	m.historySearch = search
+	m.modelState = searching
+	m.filterHistory()
+}
+
+func (m *Model[T]) filterHistory() {
+	entries := m.history.history.Entries()
+	seen := map[string]bool{}
+	// Most recent entries should be matched first
+	candidates := []suggestion.Suggestion[T]{}
+	for i := len(entries) - 1; i >= 0; i-- {
+		if !seen[entries[i]] {
+			seen[entries[i]] = true
+			candidates = append(candidates, suggestion.Suggestion[T]{Text: entries[i]})
+		}
+	}
+
+	filterer := m.historyFilterer
+	if filterer == nil {
+		filterer = completer.NewFuzzyFilter[T]()
+	}
+	matches := filterer.Filter(string(m.historySearch.query), candidates)
+
+	// Filtering happens synchronously so the results can be passed to the dropdown directly
+	m.historySearch.manager.UnselectSuggestion()
+	m.historySearch.sequenceNumber++
+	m.historySearch.manager.Update(
+		suggestion.SuggestionMsg[T]{Suggestions: matches, SequenceNumber: m.historySearch.sequenceNumber},
+	)
+	if len(matches) > 0 {
+		m.historySearch.manager.SelectSuggestion(matches[0])
+	}
+}
+
+func (m Model[T]) currentHistoryMatch() *suggestion.Suggestion[T] {
+	if match := m.historySearch.manager.SelectedSuggestion(); match != nil {
+		return match
+	}
+	// Nothing selected, fall back to the best match
+	if suggestions := m.historySearch.manager.Suggestions(); len(suggestions) > 0 {
+		return &suggestions[0]
+	}
+	return nil
+}
+
+func (m *Model[T]) finishHistorySearch(accept bool) tea.Cmd {
+	match := m.currentHistoryMatch()
+	m.historySearch = nil
+	m.modelState = completing
+	if !accept || match == nil {
+		// The input wasn't modified during the search so there's nothing to restore
+		return nil
+	}
+
+	m.history.reset()
+	m.suggestionManager.UnselectSuggestion()
+	m.textInput.SetValue(match.Text)
+	m.textInput.SetCursor(len([]rune(match.Text)))
+	// Treat the selected entry as if the user typed it
+	m.typedRunes = m.textInput.Runes()
+	m.lastTypedCursorPosition = m.textInput.CursorOffset()
+	m.lastTypedCursorIndex = m.textInput.CursorIndex()
+
+	return m.suggestionManager.UpdateSuggestions()
+}
+
+func (m *Model[T]) updateSearching(msg tea.Msg, cmds []tea.Cmd) ([]tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.updateWindowSizeMsg(msg)
+	case tea.KeyMsg:
+		if !m.focus {
+			return cmds, false
+		}
+		switch {
+		case key.Matches(msg, m.keyMap.Submit):
+			cmds = append(cmds, m.finishHistorySearch(true))
+		case key.Matches(msg, m.keyMap.CancelHistorySearch):
+			cmds = append(cmds, m.finishHistorySearch(false))
+		case key.Matches(msg, m.keyMap.HistorySearch):
+			// Move to the next older match
+			m.historySearch.manager.NextSuggestion()
+		case m.keyMap.Suggestion.Matches(msg):
+			m.historySearch.manager.Update(msg)
+		case msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace:
+			m.historySearch.query = append(m.historySearch.query, msg.Runes...)
+			m.filterHistory()
+		case msg.Type == tea.KeyBackspace:
+			if len(m.historySearch.query) > 0 {
+				m.historySearch.query = m.historySearch.query[:len(m.historySearch.query)-1]
+				m.filterHistory()
+			}
+		}
+		return cmds, true
+	}
+	return cmds, false
+}
+
+func (m Model[T]) historySearchPrefix() string {
+	prefix := historySearchPrompt
+	if len(m.historySearch.query) > 0 && len(m.historySearch.manager.Suggestions()) == 0 {
+		prefix = failedHistorySearchPrompt
+	}
+	return prefix + "`" + string(m.historySearch.query) + "': "
+}
+
+func (m Model[T]) renderHistorySearchInput() string {
+	view := m.historySearchPrefix()
+	if match := m.currentHistoryMatch(); match != nil {
+		view += match.Text
+	}
+	return view
+}
+
+func (m Model[T]) renderHistorySearchSuggestions() string {
+	// Line the matches up with the match shown in the search line
+	paddingSize := runewidth.StringWidth(m.historySearchPrefix()) -
+		runewidth.StringWidth(m.historySearch.manager.SelectionIndicator())
+	if paddingSize < 0 {
+		paddingSize = 0
+	}
+	return m.historySearch.manager.Render(paddingSize)
+}
@@ -0,0 +1,64 @@
+package prompt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aschey/bubbleprompt/history"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var (
+	keyCtrlR  = tea.KeyMsg{Type: tea.KeyCtrlR}
+	keyEscape = tea.KeyMsg{Type: tea.KeyEscape}
+)
+
+func TestHistorySearch(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		keys      []tea.KeyMsg
+		want      string
+		searching bool
+		input     string
+	}{
+		{name: "newest match", query: "get", want: "", searching: true, input: "`get': get two"},
+		{name: "accept newest match", query: "get", keys: []tea.KeyMsg{keyEnter}, want: "get two"},
+		{name: "older match", query: "get", keys: []tea.KeyMsg{keyCtrlR, keyEnter}, want: "get one"},
+		{name: "cancel", query: "get", keys: []tea.KeyMsg{keyEscape}, want: ""},
+		{
+			name:      "no match",
+			query:     "zzz",
+			searching: true,
+			input:     "(failed reverse-i-search)`zzz': ",
+		},
+		{
+			name:  "backspace",
+			query: "sez",
+			keys:  []tea.KeyMsg{{Type: tea.KeyBackspace}, {Type: tea.KeyEnter}},
+			want:  "set alarm",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := newTestPrompt(t, &testHandler{}, WithHistory[any](history.NewMemoryHistory()))
+			p.submit("get one")
+			p.submit("set alarm")
+			p.submit("get two")
+			p.send(keyCtrlR)
+			p.typeText(test.query)
+			for _, key := range test.keys {
+				p.send(key)
+			}
+			if p.value() != test.want {
+				t.Errorf("value = %q, want %q", p.value(), test.want)
+			}
+			if searching := p.model.modelState == searching; searching != test.searching {
+				t.Errorf("searching = %v, want %v", searching, test.searching)
+			}
+			if input := strings.Split(p.view(), "\n")[0]; !strings.Contains(input, test.input) {
+				t.Errorf("input %q doesn't contain %q", input, test.input)
+			}
+		})
+	}
+}
@@ -16,7 +16,13 @@ type CommandMetadata[T any] struct {
 	PreservePlaceholder bool
 	Variadic            bool
 	Children            []suggestion.Suggestion[CommandMetadata[T]]
-	Extra               T
+	// Flag is the flag that this suggestion was generated from (if applicable).
+	// It's set automatically by [Model.FlagSuggestions].
+	Flag *FlagInput
+	// Flags is the list of flags that the command accepts (optional).
+	// It's used to validate flags that were typed without selecting a suggestion.
+	Flags []FlagInput
+	Extra T
 }
 
 // MetadataFromPositionalArgs is a convenience function for creating a [CommandMetadata]
@@ -38,16 +38,42 @@ func newCmdViewBuilder[T any](
 }
 
 func (b commandViewBuilder[T]) View() string {
-	b.renderArgs()
-	b.renderFlags()
-	b.renderPlaceholders()
-	b.renderFlagPlaceholder()
-	b.renderFlagsPlaceholder()
+	pipeline := b.model.parsedPipeline
+	statements := pipeline.statements()
+	for i, statement := range statements {
+		if i > 0 {
+			operator := pipeline.Segments[i-1].Operator
+			b.render([]rune(operator.Value), operator.Pos.Column, b.model.formatters.Operator)
+		}
+		// Placeholders are only shown at the end of the input so they don't push the rest of the text over
+		if b.showAutosuggestion() {
+			b.renderStatement(*statement, i == b.model.segmentIndex)
+		} else if i == b.model.segmentIndex && i == len(statements)-1 && !pipeline.isRedirectTarget(i) {
+			b.renderArgs()
+			b.renderFlags()
+			b.renderPlaceholders()
+			b.renderFlagPlaceholder()
+			b.renderFlagsPlaceholder()
+		} else {
+			b.renderStatement(*statement, i == b.model.segmentIndex)
+		}
+	}
 	b.renderTrailingText()
+	if b.showAutosuggestion() {
+		b.viewBuilder.RenderPlaceholder(
+			[]rune(b.model.autosuggestion),
+			b.viewBuilder.ViewLen(),
+			b.model.formatters.Autosuggestion,
+		)
+	}
 
 	return b.model.formatters.Prompt.Render(string(b.model.prompt)) + b.viewBuilder.View()
 }
 
+func (b commandViewBuilder[T]) showAutosuggestion() bool {
+	return b.showPlaceholders && b.model.autosuggestion != ""
+}
+
 func (b commandViewBuilder[T]) render(runes []rune, column int, style lipgloss.Style) {
 	if b.currentState.selectedToken != nil && b.currentState.selectedToken.Start == column-1 {
 		b.viewBuilder.Render(runes, column, b.model.formatters.SelectedText)
@@ -71,6 +97,22 @@ func (b commandViewBuilder[T]) renderArgs() {
 	}
 }
 
+// renderStatement renders a statement without any placeholders.
+func (b commandViewBuilder[T]) renderStatement(statement statement, isCurrent bool) {
+	command := statement.Command
+	b.render([]rune(command.Value), command.Pos.Column, b.model.formatters.Command)
+	for _, arg := range statement.Args.Value {
+		b.render([]rune(arg.Value), arg.Pos.Column, b.model.formatters.PositionalArg.Arg)
+	}
+	parsedFlags := statement.toStatement().Flags
+	for i, flag := range statement.Flags.Value {
+		b.renderFlag(flag, b.model.flagInput(command.Value, parsedFlags[i], isCurrent))
+	}
+	for _, text := range statement.TrailingText {
+		b.render([]rune(text.Value), text.Pos.Column, lipgloss.NewStyle())
+	}
+}
+
 func (b commandViewBuilder[T]) renderCurrentArg(arg string, suggestion *suggestion.Suggestion[CommandMetadata[T]]) {
 	if len(arg) > 0 && suggestion != nil && strings.HasPrefix(suggestion.GetSuggestionText(), arg) {
 		tokenPos := len([]rune(arg))
@@ -81,9 +123,10 @@ func (b commandViewBuilder[T]) renderCurrentArg(arg string, suggestion *suggesti
 
 func (b commandViewBuilder[T]) renderFlags() {
 	flags := b.model.parsedText.Flags.Value
+	parsedFlags := b.model.parsedText.toStatement().Flags
 
 	for i, flag := range flags {
-		b.renderFlag(i, flag)
+		b.renderFlag(flag, b.model.flagInput(b.model.parsedText.Command.Value, parsedFlags[i], true))
 	}
 
 	if len(flags) > 0 && b.currentState.isFlagSuggestion() {
@@ -92,8 +135,8 @@ func (b commandViewBuilder[T]) renderFlags() {
 }
 
 func (b commandViewBuilder[T]) renderFlag(
-	i int,
 	flag flag,
+	flagInput *FlagInput,
 ) {
 	flagNameRunes := []rune(flag.Name)
 
@@ -108,7 +151,11 @@ func (b commandViewBuilder[T]) renderFlag(
 	}
 
 	if flag.Value != nil {
-		b.render([]rune(flag.Value.Value), flag.Value.Pos.Column, b.flagValueStyle(flag.Value.Value))
+		b.render(
+			[]rune(flag.Value.Value),
+			flag.Value.Pos.Column,
+			b.flagValueStyle(flag.Value.Value, flagInput),
+		)
 	}
 }
 
@@ -209,7 +256,24 @@ func (b commandViewBuilder[T]) renderTrailingText() {
 	}
 }
 
-func (b commandViewBuilder[T]) flagValueStyle(value string) lipgloss.Style {
+func (b commandViewBuilder[T]) flagValueStyle(value string, flagInput *FlagInput) lipgloss.Style {
+	if flagInput != nil {
+		unquoted := input.Token{Value: value}.Unquote()
+		if flagInput.ValidateValue(unquoted) != nil {
+			return b.model.formatters.FlagValue.Error
+		}
+		switch flagInput.ValueType {
+		case FlagValueString, FlagValueDuration, FlagValueEnum, FlagValueCustom:
+			return b.model.formatters.FlagValue.String
+		case FlagValueBool:
+			return b.model.formatters.FlagValue.Bool
+		case FlagValueInt, FlagValueFloat:
+			return b.model.formatters.FlagValue.Number
+		case FlagValuePath:
+			return b.model.formatters.FlagValue.Path
+		}
+	}
+
 	if _, err := strconv.ParseInt(value, 10, 32); err == nil {
 		return b.model.formatters.FlagValue.Number
 	} else if _, err := strconv.ParseBool(value); err == nil {
@@ -0,0 +1,125 @@
+package commandinput
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FlagValueType is the data type of a flag's argument.
+// It's used to determine how the argument is styled and validated.
+type FlagValueType int
+
+const (
+	// FlagValueUntyped infers the type from the argument text. No validation is performed.
+	FlagValueUntyped FlagValueType = iota
+	FlagValueString
+	FlagValueBool
+	FlagValueInt
+	FlagValueFloat
+	FlagValueDuration
+	// FlagValueEnum only accepts one of the values from [FlagInput.EnumValues].
+	FlagValueEnum
+	FlagValuePath
+	// FlagValueCustom uses [FlagInput.Validate] to validate the argument.
+	FlagValueCustom
+)
+
+// ValidateValue checks whether the value is valid for the flag's ValueType.
+// If the flag has a Validate function, it's invoked after the type check succeeds.
+// Flags with a ValueType of [FlagValueCustom] always fail validation if Validate isn't set.
+func (f FlagInput) ValidateValue(value string) error {
+	var err error
+	switch f.ValueType {
+	case FlagValueBool:
+		_, err = strconv.ParseBool(value)
+	case FlagValueInt:
+		_, err = strconv.ParseInt(value, 10, 64)
+	case FlagValueFloat:
+		_, err = strconv.ParseFloat(value, 64)
+	case FlagValueDuration:
+		_, err = time.ParseDuration(value)
+	case FlagValueEnum:
+		if !slices.Contains(f.EnumValues, value) {
+			err = fmt.Errorf("must be one of %s", strings.Join(f.EnumValues, ", "))
+		}
+	case FlagValueCustom:
+		if f.Validate == nil {
+			return fmt.Errorf("flag %s has a custom value type but no Validate function", f.displayName())
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("invalid value %q for flag %s: %w", value, f.displayName(), err)
+	}
+	if f.Validate != nil {
+		if err := f.Validate(value); err != nil {
+			return fmt.Errorf("invalid value %q for flag %s: %w", value, f.displayName(), err)
+		}
+	}
+	return nil
+}
+
+func (f FlagInput) displayName() string {
+	if f.Long != "" {
+		return f.LongFlag()
+	}
+	return f.ShortFlag()
+}
+
+// FindFlag returns the first flag that matches any of the supplied names.
+// Names can be supplied with or without the leading dashes.
+func (s Statement) FindFlag(names ...string) (Flag, bool) {
+	for _, flag := range s.Flags {
+		flagName := strings.TrimLeft(flag.Name.Value, "-")
+		for _, name := range names {
+			if strings.TrimLeft(name, "-") == flagName {
+				return flag, true
+			}
+		}
+	}
+	return Flag{}, false
+}
+
+// StringValue returns the flag's argument with any surrounding quotes removed.
+// If the flag has no argument, an empty string is returned.
+func (f Flag) StringValue() string {
+	if f.Value == nil {
+		return ""
+	}
+	return f.Value.Unquote()
+}
+
+// BoolValue parses the flag's argument as a bool.
+// A flag without an argument is considered to be true.
+func (f Flag) BoolValue() (bool, error) {
+	if f.Value == nil {
+		return true, nil
+	}
+	return strconv.ParseBool(f.StringValue())
+}
+
+// IntValue parses the flag's argument as an int.
+func (f Flag) IntValue() (int, error) {
+	if f.Value == nil {
+		return 0, fmt.Errorf("flag %s requires a value", f.Name.Value)
+	}
+	return strconv.Atoi(f.StringValue())
+}
+
+// FloatValue parses the flag's argument as a float.
+func (f Flag) FloatValue() (float64, error) {
+	if f.Value == nil {
+		return 0, fmt.Errorf("flag %s requires a value", f.Name.Value)
+	}
+	return strconv.ParseFloat(f.StringValue(), 64)
+}
+
+// DurationValue parses the flag's argument as a [time.Duration].
+func (f Flag) DurationValue() (time.Duration, error) {
+	if f.Value == nil {
+		return 0, fmt.Errorf("flag %s requires a value", f.Name.Value)
+	}
+	return time.ParseDuration(f.StringValue())
+}
@@ -0,0 +1,31 @@
+package commandinput_test
+
+import (
+	"fmt"
+
+	"github.com/aschey/bubbleprompt/input/commandinput"
+)
+
+func ExampleFlagInput_ValidateValue() {
+	flag := commandinput.FlagInput{
+		Long:       "units",
+		ValueType:  commandinput.FlagValueEnum,
+		EnumValues: []string{"metric", "imperial"},
+	}
+
+	fmt.Println(flag.ValidateValue("metric"))
+	fmt.Println(flag.ValidateValue("kelvin"))
+	// Output:
+	// <nil>
+	// invalid value "kelvin" for flag --units: must be one of metric, imperial
+}
+
+func ExampleStatement_FindFlag() {
+	textInput := commandinput.New[any]()
+	textInput.SetValue("get weather --days 3")
+
+	flag, _ := textInput.ParsedValue().FindFlag("d", "days")
+	days, _ := flag.IntValue()
+	fmt.Println(days)
+	// Output: 3
+}
@@ -10,6 +10,10 @@ var (
 	DefaultFlagPlaceholderForeground    = "14"
 	DefaultBoolFlagForeground           = "13"
 	DefaultNumberFlagForeground         = "5"
+	DefaultPathFlagForeground           = "12"
+	DefaultFlagErrorForeground          = "9"
+	DefaultOperatorForeground           = "3"
+	DefaultAutosuggestionForeground     = "242"
 )
 
 // PositionalArgFormatter handles styling for positional arguments.
@@ -36,6 +40,10 @@ type FlagValueFormatter struct {
 	Bool lipgloss.Style
 	// Number handles styling for numeric values.
 	Number lipgloss.Style
+	// Path handles styling for file path values.
+	Path lipgloss.Style
+	// Error handles styling for values that fail validation.
+	Error lipgloss.Style
 }
 
 // Formatters handles styling for the command input.
@@ -52,6 +60,10 @@ type Formatters struct {
 	Prompt lipgloss.Style
 	// Command handles styling for the command.
 	Command lipgloss.Style
+	// Operator handles styling for the operators that join statements when pipelines are enabled.
+	Operator lipgloss.Style
+	// Autosuggestion handles styling for the ghost text that's shown after the cursor when autosuggestions are enabled.
+	Autosuggestion lipgloss.Style
 	// SelectedText handles styling for the text that's selected by the suggestion manager.
 	SelectedText lipgloss.Style
 	// Cursor handles styling for the cursor.
@@ -68,6 +80,12 @@ func DefaultFormatters() Formatters {
 		SelectedText: lipgloss.
 			NewStyle().
 			Foreground(lipgloss.Color(DefaultSelectedTextColor)),
+		Autosuggestion: lipgloss.
+			NewStyle().
+			Foreground(lipgloss.Color(DefaultAutosuggestionForeground)),
+		Operator: lipgloss.
+			NewStyle().
+			Foreground(lipgloss.Color(DefaultOperatorForeground)),
 		PositionalArg: PositionalArgFormatter{
 			Placeholder: lipgloss.
 				NewStyle().
@@ -88,6 +106,12 @@ func DefaultFormatters() Formatters {
 			Number: lipgloss.
 				NewStyle().
 				Foreground(lipgloss.Color(DefaultNumberFlagForeground)),
+			Path: lipgloss.
+				NewStyle().
+				Foreground(lipgloss.Color(DefaultPathFlagForeground)),
+			Error: lipgloss.
+				NewStyle().
+				Foreground(lipgloss.Color(DefaultFlagErrorForeground)),
 		},
 	}
 }
@@ -3,6 +3,7 @@
 package commandinput
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
@@ -24,6 +25,8 @@ type PositionalArg struct {
 
 	PlaceholderStyle lipgloss.Style
 	ArgStyle         lipgloss.Style
+	// Values supplies suggestions for the arg while the cursor is on it (optional).
+	Values ValueSource
 }
 
 // Placeholder returns the text value of the placeholder text.
@@ -54,6 +57,16 @@ type FlagInput struct {
 	ArgPlaceholder FlagArgPlaceholder
 	// Description is the flag description.
 	Description string
+	// ValueType is the data type of the flag argument (if applicable).
+	ValueType FlagValueType
+	// EnumValues is the list of allowed values when the ValueType is [FlagValueEnum].
+	EnumValues []string
+	// Validate is an optional function for additional validation of the flag argument.
+	// It's required when the ValueType is [FlagValueCustom].
+	Validate func(value string) error
+	// Values supplies suggestions for the flag argument while the cursor is on it (optional).
+	// Enum and path flags use EnumValues and a [completer.PathCompleter] if no source is supplied.
+	Values ValueSource
 }
 
 // ShortFlag returns the Short property formatted as a flag with a leading dash.
@@ -103,9 +116,17 @@ type Model[T any] struct {
 	defaultDelimiter string
 	delimiterRegex   *regexp.Regexp
 	formatters       Formatters
-	parser           parser.Parser[statement]
-	parsedText       *statement
-	states           []modelState[T]
+	parser           parser.Parser[pipeline]
+	pipelines        bool
+	parsedPipeline   *pipeline
+	// parsedText is the statement in the pipeline that's under the cursor
+	parsedText   *statement
+	segmentIndex int
+	states       []modelState[T]
+	// commandFlags contains the flags that each command accepts so typed flags can be validated by name
+	commandFlags map[string][]FlagInput
+	// autosuggestion is the ghost text shown after the cursor
+	autosuggestion string
 }
 
 // New creates a new model.
@@ -113,19 +134,22 @@ func New[T any](opts ...Option[T]) *Model[T] {
 	textinput := textinput.New()
 
 	formatters := DefaultFormatters()
+	parsedPipeline := &pipeline{}
 	model := &Model[T]{
 		textinput:        textinput,
 		prompt:           "> ",
 		formatters:       formatters,
-		parsedText:       &statement{},
+		parsedPipeline:   parsedPipeline,
+		parsedText:       &parsedPipeline.First,
 		delimiterRegex:   regexp.MustCompile(`\s+`),
 		defaultDelimiter: " ",
+		commandFlags:     map[string][]FlagInput{},
 	}
 	for _, opt := range opts {
 		opt(model)
 	}
 
-	model.parser = buildCliParser(model.delimiterRegex.String())
+	model.parser = buildCliParser(model.delimiterRegex.String(), model.pipelines)
 	return model
 }
 
@@ -237,8 +261,9 @@ func (m *Model[T]) ArgsBeforeCursor() []string {
 	runesBeforeCursor := m.Runes()[:m.CursorIndex()]
 
 	expr, _ := m.parser.Parse(string(runesBeforeCursor))
+	current, _ := expr.segmentAt(len(runesBeforeCursor))
 
-	for _, arg := range expr.Args.Value {
+	for _, arg := range current.Args.Value {
 		args = append(args, arg.Value)
 	}
 	return args
@@ -252,12 +277,13 @@ func (m *Model[T]) CompletedArgsBeforeCursor() []string {
 	runesBeforeCursor := m.Runes()[:m.CursorIndex()]
 
 	expr, _ := m.parser.Parse(string(runesBeforeCursor))
+	current, _ := expr.segmentAt(len(runesBeforeCursor))
 
-	for _, arg := range expr.Args.Value {
+	for _, arg := range current.Args.Value {
 		args = append(args, arg.Value)
 	}
 
-	if len(expr.Flags.Value) == 0 && len(runesBeforeCursor) > 0 &&
+	if len(current.Flags.Value) == 0 && len(runesBeforeCursor) > 0 &&
 		!m.isDelimiter(string(runesBeforeCursor[len(runesBeforeCursor)-1])) {
 		if len(args) > 0 {
 			args = args[:len(args)-1]
@@ -274,8 +300,9 @@ func (m *Model[T]) OnUpdateStart(msg tea.Msg) tea.Cmd {
 	if _, ok := msg.(tea.KeyMsg); ok {
 		expr, err := m.parser.Parse(m.Value())
 		if err == nil {
-			m.parsedText = expr
+			m.parsedPipeline = expr
 		}
+		m.selectSegment()
 	}
 	allTokens := m.Tokens()
 	tokenLen := len(allTokens)
@@ -289,7 +316,7 @@ func (m *Model[T]) OnUpdateStart(msg tea.Msg) tea.Cmd {
 		m.states = m.states[:tokenLen]
 	}
 
-	if current.Index < len(m.states) {
+	if current.Index >= 0 && current.Index < len(m.states) {
 		variadicStart := m.states[current.Index].variadicTokenStart
 		// Starting another token after variadic arguments
 		// Ensure we have a quote before the first variadic token so everything after gets counted as a single token
@@ -314,12 +341,15 @@ func (m *Model[T]) OnUpdateStart(msg tea.Msg) tea.Cmd {
 
 // FlagSuggestions generates a list of [suggestion.Suggestion] based on
 // the input string and the list of [FlagInput] supplied.
+// The flags should be all of the flags that the current command accepts.
+// They're also used to validate flags that the user typed without selecting a suggestion.
 // The last parameter can be used to customize the metadata for the returned suggestions.
 func (m *Model[T]) FlagSuggestions(
 	inputStr string,
 	flags []FlagInput,
 	suggestionFunc func(FlagInput) CommandMetadata[T],
 ) []suggestion.Suggestion[CommandMetadata[T]] {
+	m.setCommandFlags(m.parsedText.Command.Value, flags)
 	inputRunes := []rune(inputStr)
 	suggestions := []suggestion.Suggestion[CommandMetadata[T]]{}
 	isLong := strings.HasPrefix(inputStr, "--")
@@ -405,10 +435,71 @@ func (m *Model[T]) getFlagSuggestion(
 	} else {
 		suggestion.Metadata = suggestionFunc(flag)
 	}
+	if suggestion.Metadata.Flag == nil {
+		suggestion.Metadata.Flag = &flag
+	}
 
 	return suggestion
 }
 
+func (m *Model[T]) setCommandFlags(command string, flags []FlagInput) {
+	if command != "" && len(flags) > 0 {
+		m.commandFlags[command] = flags
+	}
+}
+
+// flagInput returns the [FlagInput] that describes the flag, if it's known.
+// The flag that was selected from the suggestions is preferred since the same name
+// could be generated by different suggestions. Otherwise, the flag is looked up by name
+// from the flags that the command accepts.
+func (m Model[T]) flagInput(command string, flag Flag, isCurrent bool) *FlagInput {
+	index := flag.Name.Index
+	if flag.Value != nil {
+		index = flag.Value.Index
+	}
+	if isCurrent && index < len(m.states) {
+		if selectedFlag := m.states[index].selectedFlag; selectedFlag != nil && selectedFlag.Metadata.Flag != nil {
+			return selectedFlag.Metadata.Flag
+		}
+	}
+
+	name := strings.TrimLeft(flag.Name.Value, "-")
+	if name == "" {
+		return nil
+	}
+	for _, flagInput := range m.commandFlags[command] {
+		if strings.TrimLeft(flagInput.Short, "-") == name || strings.TrimLeft(flagInput.Long, "-") == name {
+			return &flagInput
+		}
+	}
+	return nil
+}
+
+// ValidateFlags validates the value of each flag in the input.
+// Flags are matched by name against the flags that were passed to [Model.FlagSuggestions]
+// or set in [CommandMetadata.Flags] for the statement's command.
+// Flags that don't match any known flag are not validated.
+func (m Model[T]) ValidateFlags() error {
+	errs := []error{}
+	for i, statement := range m.parsedPipeline.statements() {
+		parsed := statement.toStatement()
+		for _, flag := range parsed.Flags {
+			flagInput := m.flagInput(parsed.Command.Value, flag, i == m.segmentIndex)
+			if flagInput == nil || !flagInput.RequiresArg() {
+				continue
+			}
+			if flag.Value == nil {
+				errs = append(errs, fmt.Errorf("flag %s requires a value", flag.Name.Value))
+				continue
+			}
+			if err := flagInput.ValidateValue(flag.Value.Unquote()); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // OnUpdateFinish is part of the [input.Input] interface.
 // It should not be invoked by users of this library.
 func (m *Model[T]) OnUpdateFinish(
@@ -420,6 +511,10 @@ func (m *Model[T]) OnUpdateFinish(
 	if index > len(m.states) {
 		panic("Completer returned multiple tokens")
 	}
+	if index < 0 {
+		// The cursor is before the first token
+		return nil
+	}
 	m.states[index].selectedSuggestion = suggestion
 
 	if index > 0 {
@@ -438,6 +533,9 @@ func (m *Model[T]) OnUpdateFinish(
 	}
 
 	if suggestion != nil {
+		if index == 0 && suggestion.Text == m.parsedText.Command.Value {
+			m.setCommandFlags(suggestion.Text, suggestion.Metadata.Flags)
+		}
 		if len(suggestion.Metadata.PositionalArgs) > 0 {
 			m.states[index].subcommand = suggestion
 			m.states[index].argNumber = 0
@@ -461,10 +559,10 @@ func (m *Model[T]) OnSuggestionChanged(suggestion suggestion.Suggestion[CommandM
 	token := m.CurrentToken()
 	tokenRunes := []rune(token.Value)
 	suggestionRunes := []rune(suggestion.Text)
-	m.states[token.Index].selectedToken = &token
 
 	textRunes := m.Runes()
 	if token.Index > -1 {
+		m.states[token.Index].selectedToken = &token
 		cursor := m.CursorIndex()
 		// Check if we're adding an additional flag to the flag group
 		// If so, don't replace the entire token
@@ -501,13 +599,16 @@ func (m *Model[T]) OnSuggestionChanged(suggestion suggestion.Suggestion[CommandM
 
 // OnSuggestionUnselected is part of the [input.Input] interface. It should not be invoked by users of this library.
 func (m *Model[T]) OnSuggestionUnselected() {
-	m.states[m.CurrentToken().Index].selectedToken = nil
+	if index := m.CurrentToken().Index; index > -1 {
+		m.states[index].selectedToken = nil
+	}
 }
 
 // SuggestionRunes is part of the [input.Input] interface. It should not be invoked by users of this library.
 func (m Model[T]) SuggestionRunes(runes []rune) []rune {
 	expr, _ := m.parser.Parse(string(runes))
-	tokens := m.allTokens(expr)
+	statement, _ := expr.segmentAt(m.CursorIndex())
+	tokens := m.allTokens(statement)
 	token := m.currentToken(tokens, input.RoundUp).Value
 
 	return []rune(token)
@@ -536,18 +637,39 @@ func (m Model[T]) Runes() []rune {
 }
 
 // ParsedValue returns the input parsed into a [Statement].
+// If pipelines are enabled, the statement is the one under the cursor
+// and the full pipeline is available from [Statement.Pipeline].
+// When executing the input, use [Model.Pipeline] instead so every statement is run.
 func (m Model[T]) ParsedValue() Statement {
-	return (*m.parsedText).toStatement()
+	pipeline := m.Pipeline()
+	if len(pipeline.Segments) == 0 {
+		statement := m.parsedText.toStatement()
+		statement.Pipeline = pipeline
+		return statement
+	}
+	statement := pipeline.Segments[pipeline.Current].Statement
+	statement.Pipeline = pipeline
+	return statement
+}
+
+// Pipeline returns every statement in the input along with the operators that join them.
+// If pipelines aren't enabled, the pipeline contains a single statement.
+func (m Model[T]) Pipeline() Pipeline {
+	return m.parsedPipeline.toPipeline(m.segmentIndex)
 }
 
 // CommandBeforeCursor returns the portion of the command (first input token) before the cursor position.
 func (m Model[T]) CommandBeforeCursor() string {
-	parsed := m.parsedText
-	commandRunes := []rune(parsed.Command.Value)
-	if m.CursorIndex() >= len(commandRunes) {
-		return parsed.Command.Value
+	command := m.parsedText.toStatement().Command
+	commandRunes := []rune(command.Value)
+	cursor := m.CursorIndex() - command.Start
+	if cursor >= len(commandRunes) {
+		return command.Value
 	}
-	return string(commandRunes[:m.CursorIndex()])
+	if cursor < 0 {
+		return ""
+	}
+	return string(commandRunes[:cursor])
 }
 
 // SetValue overwrites the entire input with the given string.
@@ -558,7 +680,30 @@ func (m *Model[T]) SetValue(s string) {
 		fmt.Println(err)
 	}
 
-	m.parsedText = expr
+	m.parsedPipeline = expr
+	m.selectSegment()
+	m.ensureStates()
+}
+
+// selectSegment updates the current statement to the one under the cursor.
+func (m *Model[T]) selectSegment() {
+	statement, index := m.parsedPipeline.segmentAt(m.CursorIndex())
+	m.parsedText = statement
+	if index != m.segmentIndex {
+		// The states only describe the statement that was being edited
+		m.segmentIndex = index
+		m.states = []modelState[T]{{variadicTokenStart: -1}}
+		m.ensureStates()
+	}
+}
+
+// ensureStates makes sure there is a state for every token.
+// States are normally added one at a time as the user types, but setting the value
+// programmatically can add several tokens at once.
+func (m *Model[T]) ensureStates() {
+	for len(m.states) < len(m.Tokens())+1 {
+		m.states = append(m.states, modelState[T]{variadicTokenStart: -1})
+	}
 }
 
 // ResetValue clears the entire input.
@@ -581,7 +726,8 @@ func (m Model[T]) TokensBeforeCursor() []input.Token {
 	textBeforeCursor := m.Runes()[:m.CursorIndex()]
 
 	expr, _ := m.parser.Parse(string(textBeforeCursor))
-	return m.allTokens(expr)
+	statement, _ := expr.segmentAt(len(textBeforeCursor))
+	return m.allTokens(statement)
 }
 
 // ValuesBeforeCursor returns the token values of the entire input before the cursor position.
@@ -595,7 +741,7 @@ func (m Model[T]) ValuesBeforeCursor() []string {
 }
 
 func (m Model[T]) allTokens(statement *statement) []input.Token {
-	parsed := m.ParsedValue()
+	parsed := m.parsedText.toStatement()
 	tokens := []input.Token{parsed.Command}
 	tokens = append(tokens, parsed.Args...)
 	for _, flag := range parsed.Flags {
@@ -636,6 +782,7 @@ func (m Model[T]) CursorOffset() int {
 // SetCursor sets the cursor position.
 func (m *Model[T]) SetCursor(pos int) {
 	m.textinput.SetCursor(pos)
+	m.selectSegment()
 }
 
 // SetCursorMode sets the mode of the cursor.
@@ -643,6 +790,17 @@ func (m *Model[T]) SetCursorMode(cursorMode cursor.Mode) tea.Cmd {
 	return m.textinput.Cursor.SetMode(cursorMode)
 }
 
+// SetKeyMap sets the keys used to edit the text.
+func (m *Model[T]) SetKeyMap(keyMap textinput.KeyMap) {
+	m.textinput.KeyMap = keyMap
+}
+
+// SetAutosuggestion sets the text that's shown after the cursor.
+// Placeholders are hidden while the autosuggestion is shown.
+func (m *Model[T]) SetAutosuggestion(text string) {
+	m.autosuggestion = text
+}
+
 // Prompt returns the terminal prompt.
 func (m *Model[T]) Prompt() string {
 	return string(m.prompt)
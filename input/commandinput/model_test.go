@@ -91,6 +91,22 @@ func ExampleModel_FlagSuggestions() {
 	// Text: -i, Description: refresh interval, Preserve Placeholder: true
 }
 
+func ExampleModel_ValidateFlags() {
+	textInput := commandinput.New[any]()
+	// The flags are validated by name even if they were typed without selecting a suggestion
+	textInput.SetValue("get weather --days soon")
+	_ = textInput.FlagSuggestions("", []commandinput.FlagInput{
+		{
+			Long:           "days",
+			ArgPlaceholder: textInput.NewFlagPlaceholder("<number of days>"),
+			ValueType:      commandinput.FlagValueInt,
+		},
+	}, nil)
+
+	fmt.Println(textInput.ValidateFlags())
+	// Output: invalid value "soon" for flag --days: strconv.ParseInt: parsing "soon": invalid syntax
+}
+
 func ExampleModel_ParseUsage() {
 	textInput := commandinput.New[commandinput.CommandMetadata[any]]()
 
@@ -113,3 +129,29 @@ func ExampleModel_ParseUsage() {
 	// "double quoted arg"
 	// normal-arg
 }
+
+func ExampleWithPipelines() {
+	textInput := commandinput.New(commandinput.WithPipelines[any]())
+	textInput.SetValue("get weather --days 3 | grep cloudy>out.txt")
+
+	for _, segment := range textInput.Pipeline().Segments {
+		fmt.Printf("%q %s %d\n", segment.Operator, segment.Statement.Command.Value, len(segment.Statement.Args))
+		for _, redirect := range segment.Statement.Redirects {
+			fmt.Printf("%q %s\n", redirect.Operator, redirect.Target.Value)
+		}
+	}
+	fmt.Println(textInput.ParsedValue().Command.Value)
+	// Output:
+	// "" get 1
+	// "|" grep 1
+	// ">" out.txt
+	// grep
+}
+
+func ExamplePipeline_Background() {
+	textInput := commandinput.New(commandinput.WithPipelines[any]())
+	textInput.SetValue("sleep 10 &")
+
+	fmt.Println(textInput.ParsedValue().Pipeline.Background())
+	// Output: true
+}
@@ -27,3 +27,14 @@ func WithCursorMode[T any](cursorMode cursor.Mode) Option[T] {
 		model.SetCursorMode(cursorMode)
 	}
 }
+
+// WithPipelines enables parsing multiple statements joined by the operators |, ||, &&, ;, &
+// and the redirects >, >>, and <.
+// Suggestions and placeholders apply to the statement under the cursor.
+// Use [Model.Pipeline] to get every statement in the input.
+// Redirect targets are stored in [Statement.Redirects] on the statement that they apply to.
+func WithPipelines[T any]() Option[T] {
+	return func(model *Model[T]) {
+		model.pipelines = true
+	}
+}
@@ -7,27 +7,145 @@ import (
 	"github.com/aschey/bubbleprompt/parser"
 )
 
-func buildCliParser(delimiterRegex string) *parser.ParticipleParser[statement] {
+func buildCliParser(delimiterRegex string, pipelines bool) parser.Parser[pipeline] {
+	stringPattern := `[^\s]+`
+	flagPattern := `\-{1,2}[^\s=\-]*`
+	rootRules := []lexer.Rule{}
+	flagRules := []lexer.Rule{{Name: "Eq", Pattern: `\s*=\s*`, Action: lexer.Pop()}}
+	if pipelines {
+		// Operators can be placed directly next to other tokens without any whitespace
+		stringPattern = `[^\s|;<>&]+`
+		flagPattern = `\-{1,2}[^\s=\-|;<>&]*`
+		operatorPattern := `\|\||&&|\||;|>>|>|<|&`
+		rootRules = append(rootRules, lexer.Rule{Name: "Operator", Pattern: operatorPattern})
+		flagRules = append(flagRules, lexer.Rule{Name: "Operator", Pattern: operatorPattern, Action: lexer.Pop()})
+	}
+	rootRules = append(rootRules,
+		lexer.Rule{Name: "Flag", Pattern: flagPattern, Action: lexer.Push("Flag")},
+		lexer.Include("Standard"),
+		lexer.Rule{Name: "Whitespace", Pattern: delimiterRegex},
+	)
+	flagRules = append(flagRules,
+		lexer.Include("Standard"),
+		lexer.Rule{Name: "FlagWhitespace", Pattern: delimiterRegex, Action: lexer.Pop()},
+	)
+
 	lexer := lexer.MustStateful(lexer.Rules{
-		"Root": {
-			{Name: "Flag", Pattern: `\-{1,2}[^\s=\-]*`, Action: lexer.Push("Flag")},
-			lexer.Include("Standard"),
-			{Name: "Whitespace", Pattern: delimiterRegex},
-		},
+		"Root": rootRules,
 		"Standard": {
 			{Name: "QuotedString", Pattern: `("[^"]*"?)|('[^']*'?)`},
-			{Name: "String", Pattern: `[^\s]+`},
-		},
-		"Flag": {
-			{Name: "Eq", Pattern: `\s*=\s*`, Action: lexer.Pop()},
-			lexer.Include("Standard"),
-			{Name: "FlagWhitespace", Pattern: delimiterRegex, Action: lexer.Pop()},
+			{Name: "String", Pattern: stringPattern},
 		},
+		"Flag": flagRules,
 	})
+	if pipelines {
+		participleParser := participle.MustBuild[pipeline](
+			participle.Lexer(lexer),
+			participle.Elide("Whitespace", "FlagWhitespace"))
+		return parser.NewParticipleParser(participleParser)
+	}
 	participleParser := participle.MustBuild[statement](
 		participle.Lexer(lexer),
 		participle.Elide("Whitespace", "FlagWhitespace"))
-	return parser.NewParticipleParser(participleParser)
+	return statementParser{parser.NewParticipleParser(participleParser)}
+}
+
+// statementParser parses the input as a pipeline with a single statement
+// when the operators are disabled.
+type statementParser struct {
+	*parser.ParticipleParser[statement]
+}
+
+func (p statementParser) Parse(input string) (*pipeline, error) {
+	statement, err := p.ParticipleParser.Parse(input)
+	if statement == nil {
+		return nil, err
+	}
+	return &pipeline{First: *statement}, err
+}
+
+type pipeline struct {
+	Pos      lexer.Position
+	First    statement `parser:"@@"`
+	Segments []segment `parser:"@@*"`
+}
+
+type segment struct {
+	Operator  operator  `parser:"@@"`
+	Statement statement `parser:"@@"`
+}
+
+type operator struct {
+	Pos   lexer.Position
+	Value string `parser:"@Operator"`
+}
+
+func (o operator) end() int {
+	return o.Pos.Column - 1 + len([]rune(o.Value))
+}
+
+// statements returns every statement in the pipeline in order.
+func (p *pipeline) statements() []*statement {
+	if p == nil {
+		return []*statement{{}}
+	}
+	statements := []*statement{&p.First}
+	for i := range p.Segments {
+		statements = append(statements, &p.Segments[i].Statement)
+	}
+	return statements
+}
+
+// segmentAt returns the statement under the cursor along with its index in the pipeline.
+func (p *pipeline) segmentAt(cursor int) (*statement, int) {
+	if p == nil {
+		return &statement{}, 0
+	}
+	index := 0
+	for i, segment := range p.Segments {
+		if cursor >= segment.Operator.end() {
+			index = i + 1
+		}
+	}
+	return p.statements()[index], index
+}
+
+// isRedirectTarget returns whether the statement at the index is the file name for a redirect.
+func (p *pipeline) isRedirectTarget(index int) bool {
+	return p != nil && index > 0 && PipelineOperator(p.Segments[index-1].Operator.Value).IsRedirect()
+}
+
+func (p *pipeline) toPipeline(current int) Pipeline {
+	if p == nil {
+		return Pipeline{}
+	}
+	pipeline := Pipeline{
+		Segments: []PipelineSegment{{Statement: p.First.toStatement()}},
+	}
+	for i, segment := range p.Segments {
+		operator := PipelineOperator(segment.Operator.Value)
+		if operator.IsRedirect() {
+			// The redirect target is parsed as its own statement, but it belongs to the statement before it
+			owner := &pipeline.Segments[len(pipeline.Segments)-1].Statement
+			target := segment.Statement.toStatement()
+			owner.Redirects = append(owner.Redirects, Redirect{
+				Operator: operator,
+				Target:   segment.Statement.Command.ToToken(0, "redirectTarget"),
+			})
+			// Any text after the target is still part of the command, like in a shell
+			owner.Args = append(owner.Args, target.Args...)
+			owner.Flags = append(owner.Flags, target.Flags...)
+		} else {
+			pipeline.Segments = append(pipeline.Segments, PipelineSegment{
+				Operator:  operator,
+				Statement: segment.Statement.toStatement(),
+			})
+		}
+		if i+1 == current {
+			pipeline.Current = len(pipeline.Segments) - 1
+		}
+	}
+	return pipeline
 }
 
 type statement struct {
@@ -43,6 +161,63 @@ type Statement struct {
 	Command input.Token
 	Args    []input.Token
 	Flags   []Flag
+	// Redirects contains the redirects that apply to the statement, in the order they were entered.
+	// Only populated when pipelines are enabled.
+	Redirects []Redirect
+	// Pipeline contains every statement in the input when pipelines are enabled.
+	// It's only populated for the statement returned from [Model.ParsedValue].
+	Pipeline Pipeline
+}
+
+// PipelineOperator joins two statements in a pipeline.
+type PipelineOperator string
+
+const (
+	OperatorPipe           PipelineOperator = "|"
+	OperatorOr             PipelineOperator = "||"
+	OperatorAnd            PipelineOperator = "&&"
+	OperatorSequence       PipelineOperator = ";"
+	OperatorRedirectOut    PipelineOperator = ">"
+	OperatorRedirectAppend PipelineOperator = ">>"
+	OperatorRedirectIn     PipelineOperator = "<"
+	OperatorBackground     PipelineOperator = "&"
+)
+
+// IsRedirect returns whether the operator redirects to or from a file.
+// Redirects are stored in [Statement.Redirects] instead of starting a new segment.
+func (o PipelineOperator) IsRedirect() bool {
+	return o == OperatorRedirectOut || o == OperatorRedirectAppend || o == OperatorRedirectIn
+}
+
+// Redirect sends the output of a statement to a file or reads its input from one.
+type Redirect struct {
+	Operator PipelineOperator
+	// Target is the file name. Its value is empty if the user hasn't typed it yet.
+	Target input.Token
+}
+
+// PipelineSegment is a single statement in a pipeline.
+type PipelineSegment struct {
+	// Operator joins the statement to the previous segment. It's empty for the first segment.
+	Operator  PipelineOperator
+	Statement Statement
+}
+
+// Pipeline is the input split into statements that are joined by operators.
+// Handlers should execute the whole pipeline rather than only the statement under the cursor.
+type Pipeline struct {
+	Segments []PipelineSegment
+	// Current is the index of the segment under the cursor.
+	Current int
+}
+
+// Background returns whether the input ends with [OperatorBackground].
+func (p Pipeline) Background() bool {
+	if len(p.Segments) < 2 {
+		return false
+	}
+	last := p.Segments[len(p.Segments)-1]
+	return last.Operator == OperatorBackground && last.Statement.Command.Value == ""
 }
 
 func (s statement) toStatement() Statement {
@@ -0,0 +1,117 @@
+package commandinput
+
+import (
+	"strings"
+
+	"github.com/aschey/bubbleprompt/completer"
+	"github.com/aschey/bubbleprompt/suggestion"
+)
+
+// ValueSource supplies suggestions for the value of a positional arg or flag.
+// The supplied prefix is the portion of the value before the cursor.
+// [completer.PathCompleter] can be used as a ValueSource to complete file paths.
+type ValueSource interface {
+	Complete(prefix string) []suggestion.Suggestion[any]
+}
+
+// ValueFunc is a [ValueSource] that generates suggestions from a function.
+// The function is responsible for filtering the results.
+type ValueFunc func(prefix string) []suggestion.Suggestion[any]
+
+// Complete is part of the [ValueSource] interface.
+func (f ValueFunc) Complete(prefix string) []suggestion.Suggestion[any] {
+	return f(prefix)
+}
+
+type staticValues struct {
+	suggestions []suggestion.Suggestion[any]
+	filterer    completer.Filterer[any]
+}
+
+// StaticValues creates a [ValueSource] that suggests values from a fixed list
+// that start with the text before the cursor.
+func StaticValues(values ...string) ValueSource {
+	suggestions := []suggestion.Suggestion[any]{}
+	for _, value := range values {
+		suggestions = append(suggestions, suggestion.Suggestion[any]{Text: value})
+	}
+	return staticValues{suggestions: suggestions, filterer: completer.NewPrefixFilter[any]()}
+}
+
+func (s staticValues) Complete(prefix string) []suggestion.Suggestion[any] {
+	return s.filterer.Filter(strings.TrimLeft(prefix, `"'`), s.suggestions)
+}
+
+func (f FlagInput) valueSource() ValueSource {
+	if f.Values != nil {
+		return f.Values
+	}
+	switch f.ValueType {
+	case FlagValueEnum:
+		return StaticValues(f.EnumValues...)
+	case FlagValuePath:
+		return &completer.PathCompleter[any]{}
+	}
+	return nil
+}
+
+// ValueSuggestions is part of the [input.SuggestionSource] interface.
+// It returns suggestions from the [ValueSource] of the positional arg or flag under the cursor.
+// File paths are suggested for the target of a redirect.
+// If neither has a source, ok is false and the input handler's completer is used instead.
+func (m *Model[T]) ValueSuggestions() (suggestions []suggestion.Suggestion[CommandMetadata[T]], ok bool) {
+	source := m.currentValueSource()
+	if source == nil {
+		return nil, false
+	}
+
+	for _, value := range source.Complete(m.CurrentTokenBeforeCursor().Value) {
+		suggestions = append(suggestions, suggestion.Suggestion[CommandMetadata[T]]{
+			Text:           value.Text,
+			SuggestionText: value.SuggestionText,
+			Description:    value.Description,
+			CursorOffset:   value.CursorOffset,
+		})
+	}
+	return suggestions, true
+}
+
+func (m *Model[T]) currentValueSource() ValueSource {
+	current := m.CurrentToken()
+	index := current.Index
+	if index == 0 && m.parsedPipeline.isRedirectTarget(m.segmentIndex) {
+		return &completer.PathCompleter[any]{}
+	}
+	if index <= 0 || index >= len(m.states) || strings.HasPrefix(current.Value, "-") {
+		return nil
+	}
+
+	tokens := m.Tokens()
+	prevToken := tokens[index-1].Value
+	if strings.HasPrefix(prevToken, "-") {
+		selectedFlag := m.states[index-1].selectedFlag
+		if selectedFlag == nil || selectedFlag.Metadata.Flag == nil {
+			return nil
+		}
+		flag := selectedFlag.Metadata.Flag
+		if !flag.RequiresArg() || (prevToken != flag.ShortFlag() && prevToken != flag.LongFlag()) {
+			return nil
+		}
+		return flag.valueSource()
+	}
+	flags := m.ParsedValue().Flags
+	if len(flags) > 0 && current.Start > flags[0].Name.Start {
+		// Positional args can't come after flags
+		return nil
+	}
+
+	state := m.states[index]
+	if state.subcommand == nil || state.argNumber == 0 {
+		return nil
+	}
+	positionalArgs := state.subcommand.Metadata.PositionalArgs
+	if state.argNumber > len(positionalArgs) {
+		return nil
+	}
+	return positionalArgs[state.argNumber-1].Values
+}
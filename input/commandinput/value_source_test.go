@@ -0,0 +1,15 @@
+package commandinput_test
+
+import (
+	"fmt"
+
+	"github.com/aschey/bubbleprompt/input/commandinput"
+)
+
+func ExampleStaticValues() {
+	source := commandinput.StaticValues("metric", "imperial")
+	for _, value := range source.Complete("im") {
+		fmt.Println(value.Text)
+	}
+	// Output: imperial
+}
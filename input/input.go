@@ -3,6 +3,7 @@ package input
 import (
 	"github.com/aschey/bubbleprompt/suggestion"
 	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -41,3 +42,19 @@ type Input[T any] interface {
 	ShouldClearSuggestions(prevRunes []rune, msg tea.KeyMsg) bool
 	ShouldUnselectSuggestion(prevRunes []rune, msg tea.KeyMsg) bool
 }
+
+// KeyMapSetter is implemented by inputs that allow the keys used to edit the text to be customized.
+type KeyMapSetter interface {
+	SetKeyMap(keyMap textinput.KeyMap)
+}
+
+// SuggestionSource is implemented by inputs that can generate suggestions for the token under the cursor.
+// If ok is true, the suggestions are used instead of calling the input handler's completer.
+type SuggestionSource[T any] interface {
+	ValueSuggestions() (suggestions []suggestion.Suggestion[T], ok bool)
+}
+
+// Autosuggester is implemented by inputs that can show an autosuggestion as ghost text after the cursor.
+type Autosuggester interface {
+	SetAutosuggestion(text string)
+}
@@ -0,0 +1,178 @@
+package lexerinput
+
+import (
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// editor handles the text editing for the input.
+// The input renders the text itself so the editor is only responsible for the value and the cursor.
+type editor interface {
+	update(msg tea.Msg) tea.Cmd
+	value() string
+	setValue(value string)
+	position() int
+	setCursor(cursor int)
+	focus() tea.Cmd
+	focused() bool
+	blur()
+	cursor() *cursor.Model
+	setKeyMap(keyMap textinput.KeyMap)
+}
+
+type singleLineEditor struct {
+	textinput textinput.Model
+}
+
+func newSingleLineEditor() *singleLineEditor {
+	return &singleLineEditor{textinput: textinput.New()}
+}
+
+func (e *singleLineEditor) update(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	e.textinput, cmd = e.textinput.Update(msg)
+	return cmd
+}
+
+func (e *singleLineEditor) value() string {
+	return e.textinput.Value()
+}
+
+func (e *singleLineEditor) setValue(value string) {
+	e.textinput.SetValue(value)
+}
+
+func (e *singleLineEditor) position() int {
+	return e.textinput.Position()
+}
+
+func (e *singleLineEditor) setCursor(cursor int) {
+	e.textinput.SetCursor(cursor)
+}
+
+func (e *singleLineEditor) focus() tea.Cmd {
+	return e.textinput.Focus()
+}
+
+func (e *singleLineEditor) focused() bool {
+	return e.textinput.Focused()
+}
+
+func (e *singleLineEditor) blur() {
+	e.textinput.Blur()
+}
+
+func (e *singleLineEditor) cursor() *cursor.Model {
+	return &e.textinput.Cursor
+}
+
+func (e *singleLineEditor) setKeyMap(keyMap textinput.KeyMap) {
+	e.textinput.KeyMap = keyMap
+}
+
+type multiLineEditor struct {
+	textarea textarea.Model
+}
+
+func newMultiLineEditor() *multiLineEditor {
+	textarea := textarea.New()
+	// The input handles rendering so the textarea shouldn't limit or wrap the text
+	textarea.Prompt = ""
+	textarea.ShowLineNumbers = false
+	textarea.CharLimit = 0
+	textarea.MaxHeight = 0
+	textarea.MaxWidth = 0
+	// Soft-wrapped lines would make the textarea's rows differ from the lines in the value
+	textarea.SetWidth(math.MaxInt32)
+	editor := &multiLineEditor{textarea: textarea}
+	editor.setKeyMap(textinput.DefaultKeyMap)
+	return editor
+}
+
+func (e *multiLineEditor) update(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	e.textarea, cmd = e.textarea.Update(msg)
+	return cmd
+}
+
+func (e *multiLineEditor) value() string {
+	return e.textarea.Value()
+}
+
+func (e *multiLineEditor) setValue(value string) {
+	e.textarea.SetValue(value)
+}
+
+func (e *multiLineEditor) position() int {
+	lines := strings.Split(e.textarea.Value(), "\n")
+	row := e.textarea.Line()
+	position := 0
+	for _, line := range lines[:row] {
+		// Add one to account for the newline
+		position += len([]rune(line)) + 1
+	}
+	lineInfo := e.textarea.LineInfo()
+	return position + lineInfo.StartColumn + lineInfo.ColumnOffset
+}
+
+func (e *multiLineEditor) setCursor(cursor int) {
+	lines := strings.Split(e.textarea.Value(), "\n")
+	row := 0
+	for row < len(lines)-1 && cursor > len([]rune(lines[row])) {
+		cursor -= len([]rune(lines[row])) + 1
+		row++
+	}
+	// The textarea can only move the cursor one row at a time.
+	// Lines don't wrap so each step moves to the next line.
+	for range e.textarea.Line() - row {
+		e.textarea.CursorUp()
+	}
+	for range row - e.textarea.Line() {
+		e.textarea.CursorDown()
+	}
+	e.textarea.SetCursor(cursor)
+}
+
+func (e *multiLineEditor) focus() tea.Cmd {
+	return e.textarea.Focus()
+}
+
+func (e *multiLineEditor) focused() bool {
+	return e.textarea.Focused()
+}
+
+func (e *multiLineEditor) blur() {
+	e.textarea.Blur()
+}
+
+func (e *multiLineEditor) cursor() *cursor.Model {
+	return &e.textarea.Cursor
+}
+
+func (e *multiLineEditor) setKeyMap(keyMap textinput.KeyMap) {
+	textareaKeyMap := textarea.DefaultKeyMap
+	textareaKeyMap.CharacterForward = keyMap.CharacterForward
+	textareaKeyMap.CharacterBackward = keyMap.CharacterBackward
+	textareaKeyMap.WordForward = keyMap.WordForward
+	textareaKeyMap.WordBackward = keyMap.WordBackward
+	textareaKeyMap.DeleteWordBackward = keyMap.DeleteWordBackward
+	textareaKeyMap.DeleteWordForward = keyMap.DeleteWordForward
+	textareaKeyMap.DeleteAfterCursor = keyMap.DeleteAfterCursor
+	textareaKeyMap.DeleteBeforeCursor = keyMap.DeleteBeforeCursor
+	textareaKeyMap.DeleteCharacterBackward = keyMap.DeleteCharacterBackward
+	textareaKeyMap.DeleteCharacterForward = keyMap.DeleteCharacterForward
+	textareaKeyMap.LineStart = keyMap.LineStart
+	textareaKeyMap.LineEnd = keyMap.LineEnd
+	textareaKeyMap.Paste = keyMap.Paste
+	// Enter, up, and down are used by the prompt to submit and to cycle through suggestions
+	textareaKeyMap.InsertNewline = key.NewBinding(key.WithKeys("alt+enter"), key.WithHelp("alt+enter", "insert newline"))
+	textareaKeyMap.LinePrevious = key.NewBinding(key.WithKeys("alt+up"), key.WithHelp("alt+↑", "previous line"))
+	textareaKeyMap.LineNext = key.NewBinding(key.WithKeys("alt+down"), key.WithHelp("alt+↓", "next line"))
+	e.textarea.KeyMap = textareaKeyMap
+}
@@ -2,13 +2,19 @@ package lexerinput
 
 import "github.com/charmbracelet/lipgloss"
 
-var DefaultCurrentPlaceholderSuggestion = "240"
+var (
+	DefaultCurrentPlaceholderSuggestion = "240"
+	DefaultAutosuggestionForeground     = "242"
+)
 
 // Formatters handles styling for the input.
 type Formatters struct {
 	// Placeholder handles styling for placeholder that's shown as the user types the current argument.
 	Placeholder lipgloss.Style
 
+	// Autosuggestion handles styling for the ghost text that's shown after the cursor when autosuggestions are enabled.
+	Autosuggestion lipgloss.Style
+
 	// Cursor handles styling for the cursor.
 	Cursor lipgloss.Style
 }
@@ -20,5 +26,8 @@ func DefaultFormatters() Formatters {
 		Placeholder: lipgloss.
 			NewStyle().
 			Foreground(lipgloss.Color(DefaultCurrentPlaceholderSuggestion)),
+		Autosuggestion: lipgloss.
+			NewStyle().
+			Foreground(lipgloss.Color(DefaultAutosuggestionForeground)),
 	}
 }
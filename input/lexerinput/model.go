@@ -1,6 +1,7 @@
 package lexerinput
 
 import (
+	"slices"
 	"strings"
 
 	"github.com/aschey/bubbleprompt/input"
@@ -11,36 +12,36 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/mattn/go-runewidth"
-	"golang.org/x/exp/slices"
 )
 
 type Model[T any] struct {
-	textinput         textinput.Model
-	lexer             parser.Lexer
-	tokenFormatter    parser.Formatter
-	formatters        Formatters
-	selectedToken     *input.Token
-	tokens            []input.Token
-	formatterTokens   []parser.FormatterToken
-	delimiterTokens   []string
-	delimiters        []string
-	whitespaceTokens  map[int]bool
-	prompt            string
-	currentSuggestion *string
-	err               error
+	editor             editor
+	lexer              parser.Lexer
+	tokenFormatter     parser.Formatter
+	formatters         Formatters
+	selectedToken      *input.Token
+	tokens             []input.Token
+	formatterTokens    []parser.FormatterToken
+	delimiterTokens    []string
+	delimiters         []string
+	whitespaceTokens   map[int]bool
+	prompt             string
+	continuationPrompt string
+	currentSuggestion  *string
+	autosuggestion     string
+	err                error
 }
 
 func NewModel[T any](lexer parser.Lexer, options ...Option[T]) *Model[T] {
-	textinput := textinput.New()
-
 	model := &Model[T]{
-		lexer:            lexer,
-		textinput:        textinput,
-		prompt:           "> ",
-		tokens:           []input.Token{},
-		formatterTokens:  []parser.FormatterToken{},
-		formatters:       DefaultFormatters(),
-		whitespaceTokens: make(map[int]bool),
+		lexer:              lexer,
+		editor:             newSingleLineEditor(),
+		prompt:             "> ",
+		continuationPrompt: ". ",
+		tokens:             []input.Token{},
+		formatterTokens:    []parser.FormatterToken{},
+		formatters:         DefaultFormatters(),
+		whitespaceTokens:   make(map[int]bool),
 	}
 	for _, option := range options {
 		option(model)
@@ -98,7 +99,7 @@ func (m *Model[T]) updateTokens() error {
 	if m.CursorIndex() > last {
 		fullTokens = append(
 			fullTokens,
-			m.createWhitespaceToken(last, len([]rune(m.textinput.Value())), index),
+			m.createWhitespaceToken(last, len(m.Runes()), index),
 		)
 	}
 	m.tokens = fullTokens
@@ -107,8 +108,7 @@ func (m *Model[T]) updateTokens() error {
 }
 
 func (m *Model[T]) OnUpdateStart(msg tea.Msg) tea.Cmd {
-	var cmd tea.Cmd
-	m.textinput, cmd = m.textinput.Update(msg)
+	cmd := m.editor.update(msg)
 	if msg, ok := msg.(tea.KeyMsg); ok {
 		err := m.updateTokens()
 		// Don't reset error on submit yet because we need to pass it to the view
@@ -131,17 +131,21 @@ func (m Model[T]) unstyledView(text []rune, showCursor bool, viewMode input.View
 }
 
 func (m Model[T]) styledView(
+	text []rune,
 	formatterTokens []parser.FormatterToken,
 	showCursor bool,
 	viewMode input.ViewMode,
 ) string {
 	viewBuilder := input.NewViewBuilder(m.CursorIndex(), m.formatters.Cursor, " ", showCursor)
+	// Some formatters add a trailing newline that isn't part of the text
+	remaining := len(text)
 	for _, token := range formatterTokens {
-		viewBuilder.Render(
-			[]rune(strings.TrimRight(token.Value, "\n")),
-			viewBuilder.ViewLen(),
-			token.Style,
-		)
+		tokenRunes := []rune(token.Value)
+		if len(tokenRunes) > remaining {
+			tokenRunes = tokenRunes[:remaining]
+		}
+		remaining -= len(tokenRunes)
+		viewBuilder.Render(tokenRunes, viewBuilder.ViewLen(), token.Style)
 	}
 	return m.renderWithPlaceholder(viewBuilder, viewMode)
 }
@@ -150,6 +154,10 @@ func (m Model[T]) renderWithPlaceholder(
 	viewBuilder *input.ViewBuilder,
 	viewMode input.ViewMode,
 ) string {
+	if m.autosuggestion != "" && viewMode == input.Interactive {
+		viewBuilder.RenderPlaceholder([]rune(m.autosuggestion), viewBuilder.ViewLen(), m.formatters.Autosuggestion)
+		return m.addPrompt(viewBuilder.View())
+	}
 	if m.currentSuggestion != nil && viewMode == input.Interactive {
 		current := m.CurrentToken()
 		suggestionRunes := []rune(*m.currentSuggestion)
@@ -158,16 +166,15 @@ func (m Model[T]) renderWithPlaceholder(
 			if m.IsDelimiterToken(current) {
 				if current.Index < len(m.Tokens())-1 {
 					// Cursor is before the last token, don't render placeholder
-					return m.prompt + viewBuilder.View()
+					return m.addPrompt(viewBuilder.View())
 				}
 				// Current token is a delimiter, don't try to filter it on the prefix
 				value = ""
 			}
-			suggestionRunes = suggestionRunes[len([]rune(value)):]
 			// Render placeholder only if the prefix matches
 			if strings.HasPrefix(*m.currentSuggestion, value) {
 				viewBuilder.RenderPlaceholder(
-					suggestionRunes,
+					suggestionRunes[len([]rune(value)):],
 					viewBuilder.ViewLen(),
 					m.formatters.Placeholder,
 				)
@@ -179,11 +186,21 @@ func (m Model[T]) renderWithPlaceholder(
 		}
 
 	}
-	return m.prompt + viewBuilder.View()
+	return m.addPrompt(viewBuilder.View())
+}
+
+func (m Model[T]) addPrompt(view string) string {
+	lines := strings.Split(view, "\n")
+	// Pad the continuation prompt so the text on each line stays aligned
+	continuationPrompt := m.continuationPrompt
+	if padding := runewidth.StringWidth(m.prompt) - runewidth.StringWidth(continuationPrompt); padding > 0 {
+		continuationPrompt = strings.Repeat(" ", padding) + continuationPrompt
+	}
+	return m.prompt + strings.Join(lines, "\n"+continuationPrompt)
 }
 
 func (m Model[T]) View(viewMode input.ViewMode) string {
-	showCursor := !m.textinput.Cursor.Blink
+	showCursor := !m.editor.cursor().Blink
 	if viewMode == input.Static {
 		showCursor = false
 	}
@@ -191,7 +208,7 @@ func (m Model[T]) View(viewMode input.ViewMode) string {
 		return m.unstyledView(m.Runes(), showCursor, viewMode)
 	}
 
-	return m.styledView(m.formatterTokens, showCursor, viewMode)
+	return m.styledView(m.Runes(), m.formatterTokens, showCursor, viewMode)
 }
 
 func (m Model[T]) FormatText(text string) string {
@@ -199,32 +216,32 @@ func (m Model[T]) FormatText(text string) string {
 		return m.unstyledView([]rune(text), false, input.Static)
 	}
 	formatterTokens, _ := m.tokenFormatter.Lex(text, nil)
-	return m.styledView(formatterTokens, false, input.Static)
+	return m.styledView([]rune(text), formatterTokens, false, input.Static)
 }
 
 func (m *Model[T]) Focus() tea.Cmd {
-	return m.textinput.Focus()
+	return m.editor.focus()
 }
 
 func (m Model[T]) Focused() bool {
-	return m.textinput.Focused()
+	return m.editor.focused()
 }
 
 func (m Model[T]) Value() string {
-	return m.textinput.Value()
+	return m.editor.value()
 }
 
 func (m Model[T]) Runes() []rune {
-	return []rune(m.textinput.Value())
+	return []rune(m.editor.value())
 }
 
 func (m *Model[T]) ResetValue() {
-	m.textinput.SetValue("")
+	m.editor.setValue("")
 	_ = m.updateTokens()
 }
 
 func (m *Model[T]) SetValue(value string) {
-	m.textinput.SetValue(value)
+	m.editor.setValue(value)
 	m.err = m.updateTokens()
 }
 
@@ -234,25 +251,39 @@ func (m *Model[T]) setSelectedToken(token *input.Token) {
 }
 
 func (m *Model[T]) Blur() {
-	m.textinput.Blur()
+	m.editor.blur()
 }
 
 func (m Model[T]) CursorIndex() int {
-	return m.textinput.Position()
+	return m.editor.position()
 }
 
+// CursorOffset returns the visual offset of the cursor from the start of the line that it's on.
 func (m Model[T]) CursorOffset() int {
 	cursorIndex := m.CursorIndex()
-	runesBeforeCursor := m.Runes()[:cursorIndex]
-	return runewidth.StringWidth(string(runesBeforeCursor))
+	runesBeforeCursor := string(m.Runes()[:cursorIndex])
+	if lineStart := strings.LastIndex(runesBeforeCursor, "\n"); lineStart > -1 {
+		runesBeforeCursor = runesBeforeCursor[lineStart+1:]
+	}
+	return runewidth.StringWidth(runesBeforeCursor)
 }
 
 func (m *Model[T]) SetCursor(cursor int) {
-	m.textinput.SetCursor(cursor)
+	m.editor.setCursor(cursor)
 }
 
 func (m *Model[T]) SetCursorMode(cursorMode cursor.Mode) tea.Cmd {
-	return m.textinput.Cursor.SetMode(cursorMode)
+	return m.editor.cursor().SetMode(cursorMode)
+}
+
+func (m *Model[T]) SetKeyMap(keyMap textinput.KeyMap) {
+	m.editor.setKeyMap(keyMap)
+}
+
+// SetAutosuggestion sets the text that's shown after the cursor.
+// It takes precedence over the placeholder for the current suggestion.
+func (m *Model[T]) SetAutosuggestion(text string) {
+	m.autosuggestion = text
 }
 
 // Formatters returns the formatters used by the input.
@@ -272,6 +303,15 @@ func (m *Model[T]) SetPrompt(prompt string) {
 	m.prompt = prompt
 }
 
+// ContinuationPrompt returns the prompt shown at the start of each line after the first one.
+func (m Model[T]) ContinuationPrompt() string {
+	return m.continuationPrompt
+}
+
+func (m *Model[T]) SetContinuationPrompt(prompt string) {
+	m.continuationPrompt = prompt
+}
+
 func (m Model[T]) ShouldSelectSuggestion(suggestion suggestion.Suggestion[T]) bool {
 	token := m.CurrentToken()
 	tokenStr := token.Value
@@ -42,3 +42,23 @@ func WithFormatters[T any](formatters Formatters) Option[T] {
 		model.SetFormatters(formatters)
 	}
 }
+
+// WithMultiline allows the input to span multiple lines.
+// The input is backed by a [github.com/charmbracelet/bubbles/textarea.Model] instead of a single-line text input.
+// New lines are inserted when the input handler reports that the input isn't complete or by pressing alt+enter.
+// Use alt+up and alt+down to move between lines.
+func WithMultiline[T any]() Option[T] {
+	return func(model *Model[T]) {
+		editor := newMultiLineEditor()
+		editor.cursor().SetMode(model.editor.cursor().Mode())
+		model.editor = editor
+	}
+}
+
+// WithContinuationPrompt sets the prompt shown at the start of each line after the first one when the input spans
+// multiple lines.
+func WithContinuationPrompt[T any](prompt string) Option[T] {
+	return func(model *Model[T]) {
+		model.SetContinuationPrompt(prompt)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/aschey/bubbleprompt/parser"
 	"github.com/aschey/bubbleprompt/suggestion"
 	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -176,6 +177,16 @@ func (m *Model[T]) SetCursorMode(cursorMode cursor.Mode) tea.Cmd {
 	return m.lexerModel.SetCursorMode(cursorMode)
 }
 
+// SetKeyMap sets the keys used to edit the text.
+func (m *Model[T]) SetKeyMap(keyMap textinput.KeyMap) {
+	m.lexerModel.SetKeyMap(keyMap)
+}
+
+// SetAutosuggestion sets the text that's shown after the cursor.
+func (m *Model[T]) SetAutosuggestion(text string) {
+	m.lexerModel.SetAutosuggestion(text)
+}
+
 // Prompt returns the terminal prompt.
 func (m *Model[T]) Prompt() string {
 	return m.lexerModel.Prompt()
@@ -58,3 +58,23 @@ func WithPrompt[T any](prompt string) Option[T] {
 		)
 	}
 }
+
+// WithMultiline allows the input to span multiple lines. See [lexerinput.WithMultiline].
+func WithMultiline[T any]() Option[T] {
+	return func(settings *settings[T]) {
+		settings.lexerOptions = append(
+			settings.lexerOptions,
+			lexerinput.WithMultiline[T](),
+		)
+	}
+}
+
+// WithContinuationPrompt sets the prompt shown at the start of each line after the first one.
+func WithContinuationPrompt[T any](prompt string) Option[T] {
+	return func(settings *settings[T]) {
+		settings.lexerOptions = append(
+			settings.lexerOptions,
+			lexerinput.WithContinuationPrompt[T](prompt),
+		)
+	}
+}
@@ -1,6 +1,9 @@
 package simpleinput_test
 
 import (
+	"fmt"
+
+	"github.com/aschey/bubbleprompt/input"
 	"github.com/aschey/bubbleprompt/input/simpleinput"
 	"github.com/charmbracelet/bubbles/cursor"
 	"github.com/charmbracelet/lipgloss"
@@ -32,3 +35,20 @@ func ExampleWithSelectedTextStyle() {
 func ExampleWithCursorMode() {
 	simpleinput.New(simpleinput.WithCursorMode[any](cursor.CursorStatic))
 }
+
+func ExampleWithMultiline() {
+	// Lines after the first one are prefixed with the continuation prompt.
+	// The input handler should implement prompt.MultilineInputHandler to decide when to insert a new line.
+	textInput := simpleinput.New(
+		simpleinput.WithMultiline[any](),
+		simpleinput.WithContinuationPrompt[any](". "),
+	)
+	textInput.SetValue("first\nsecond")
+
+	fmt.Println(textInput.Tokens()[2].Start)
+	fmt.Println(textInput.View(input.Static))
+	// Output:
+	// 6
+	// > first
+	// . second
+}
@@ -53,7 +53,7 @@ func (v *ViewBuilder) Render(newRunes []rune, column int, style lipgloss.Style)
 	if cursorPos >= v.viewLen && cursorPos < v.viewLen+len(newRunes) {
 		v.view += v.renderAllWithCursor(newRunes, cursorPos-v.viewLen, style)
 	} else {
-		v.view += style.Render(string(newRunes))
+		v.view += renderLines(newRunes, style)
 	}
 	v.rawView += string(newRunes)
 	v.viewLen += len(newRunes)
@@ -78,18 +78,33 @@ func (v ViewBuilder) Last() *byte {
 }
 
 func (v ViewBuilder) renderWithCursor(runes []rune, cursorPos int, s lipgloss.Style) string {
-	view := v.cursorView(string(runes[cursorPos]), s)
-	view += s.Render(string(runes[cursorPos+1:]))
+	view := ""
+	if runes[cursorPos] == '\n' {
+		// Show the cursor at the end of the line
+		view = v.cursorView(" ", s) + "\n"
+	} else {
+		view = v.cursorView(string(runes[cursorPos]), s)
+	}
+	view += renderLines(runes[cursorPos+1:], s)
 	return view
 }
 
 func (v ViewBuilder) renderAllWithCursor(runes []rune, cursorPos int, s lipgloss.Style) string {
 	view := ""
-	view += s.Render(string(runes[:cursorPos]))
+	view += renderLines(runes[:cursorPos], s)
 	view += v.renderWithCursor(runes, cursorPos, s)
 	return view
 }
 
+// renderLines styles each line separately since lipgloss pads multi-line text to a uniform width
+func renderLines(runes []rune, s lipgloss.Style) string {
+	lines := strings.Split(string(runes), "\n")
+	for i, line := range lines {
+		lines[i] = s.Render(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
 func (v ViewBuilder) cursorView(view string, s lipgloss.Style) string {
 	if !v.showCursor {
 		return s.Render(view)
@@ -0,0 +1,107 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/ansi"
+	"github.com/charmbracelet/glamour/styles"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Inline text is never wrapped, so use a width that's wider than any terminal
+const inlineMarkdownWidth = 1 << 16
+
+// Limit the size of the cache in case the text is generated dynamically
+const maxInlineMarkdownCacheSize = 1024
+
+var (
+	inlineMarkdownCache   = map[string]string{}
+	inlineMarkdownCacheMu sync.Mutex
+)
+
+// MarkdownStyle returns the glamour style that matches the terminal's color profile and background color.
+func MarkdownStyle() ansi.StyleConfig {
+	switch {
+	case lipgloss.ColorProfile() == termenv.Ascii:
+		return styles.NoTTYStyleConfig
+	case lipgloss.HasDarkBackground():
+		return styles.DarkStyleConfig
+	default:
+		return styles.LightStyleConfig
+	}
+}
+
+func renderMarkdown(markdown string, style ansi.StyleConfig, width int) (string, error) {
+	renderer, err := glamour.NewTermRenderer(
+		glamour.WithStyles(style),
+		glamour.WithColorProfile(lipgloss.ColorProfile()),
+		glamour.WithWordWrap(width),
+	)
+	if err != nil {
+		return "", err
+	}
+	return renderer.Render(markdown)
+}
+
+// RenderMarkdown renders a Markdown document that's wrapped to fit in the given width.
+func RenderMarkdown(markdown string, width int) (string, error) {
+	style := MarkdownStyle()
+	// Glamour adds the document's margin to the word wrap width
+	if style.Document.Margin != nil {
+		width -= int(*style.Document.Margin)
+	}
+	rendered, err := renderMarkdown(markdown, style, max(width, 1))
+	if err != nil {
+		return "", err
+	}
+	return strings.Trim(rendered, "\n"), nil
+}
+
+// RenderInlineMarkdown renders Markdown that's shown on a single line within text that's styled with the base style,
+// such as a suggestion description. Block elements are joined into a single line.
+// The Markdown is returned as-is if it can't be rendered.
+func RenderInlineMarkdown(markdown string, base lipgloss.Style) string {
+	// The output depends on the color profile, which can be changed at runtime
+	cacheKey := fmt.Sprintf("%d:%s", lipgloss.ColorProfile(), markdown)
+	inlineMarkdownCacheMu.Lock()
+	rendered, ok := inlineMarkdownCache[cacheKey]
+	inlineMarkdownCacheMu.Unlock()
+	if !ok {
+		style := MarkdownStyle()
+		// Remove the document's margins and color so the text lines up with the rest of the line
+		// and uses the base style
+		style.Document = ansi.StyleBlock{}
+		style.Paragraph = ansi.StyleBlock{}
+		var err error
+		rendered, err = renderMarkdown(markdown, style, inlineMarkdownWidth)
+		if err != nil {
+			return markdown
+		}
+		lines := []string{}
+		for _, line := range strings.Split(rendered, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				lines = append(lines, line)
+			}
+		}
+		rendered = strings.Join(lines, " ")
+
+		inlineMarkdownCacheMu.Lock()
+		if len(inlineMarkdownCache) >= maxInlineMarkdownCacheSize {
+			clear(inlineMarkdownCache)
+		}
+		inlineMarkdownCache[cacheKey] = rendered
+		inlineMarkdownCacheMu.Unlock()
+	}
+
+	// Glamour resets all attributes at the end of each styled section, so the base style needs to be re-applied
+	// to keep things like the background color consistent
+	prefix, _, _ := strings.Cut(lipgloss.NewStyle().Inherit(base).Render("X"), "X")
+	if prefix == "" {
+		return rendered
+	}
+	return prefix + strings.ReplaceAll(rendered, "\x1b[0m", "\x1b[0m"+prefix)
+}
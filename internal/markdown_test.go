@@ -0,0 +1,99 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func setColorProfile(t *testing.T, profile termenv.Profile) {
+	t.Helper()
+	prevProfile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(profile)
+	t.Cleanup(func() { lipgloss.SetColorProfile(prevProfile) })
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	setColorProfile(t, termenv.Ascii)
+	markdown := "# Title\n\nSome long text that should wrap across several lines when the width is small.\n\n" +
+		"- item one\n- item two\n"
+	for _, width := range []int{20, 40, 80} {
+		rendered, err := RenderMarkdown(markdown, width)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.HasPrefix(rendered, "\n") || strings.HasSuffix(rendered, "\n") {
+			t.Errorf("width %d: rendered text has surrounding newlines: %q", width, rendered)
+		}
+		for _, line := range strings.Split(rendered, "\n") {
+			if lipgloss.Width(line) > width {
+				t.Errorf("width %d: line %q is too wide", width, line)
+			}
+		}
+		for _, want := range []string{"# Title", "• item one", "• item two"} {
+			if !strings.Contains(rendered, want) {
+				t.Errorf("width %d: rendered text %q doesn't contain %q", width, rendered, want)
+			}
+		}
+	}
+}
+
+func TestRenderMarkdownNarrow(t *testing.T) {
+	setColorProfile(t, termenv.Ascii)
+	// The width is smaller than the document's margin
+	rendered, err := RenderMarkdown("text", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(rendered, "text") {
+		t.Errorf("rendered text = %q, want it to contain %q", rendered, "text")
+	}
+}
+
+func TestRenderInlineMarkdown(t *testing.T) {
+	setColorProfile(t, termenv.Ascii)
+	tests := []struct {
+		name     string
+		markdown string
+		want     string
+	}{
+		{name: "plain", markdown: "plain text", want: "plain text"},
+		{name: "code", markdown: "run `go test`", want: "run go test"},
+		{name: "paragraphs", markdown: "first\n\nsecond", want: "first second"},
+		{name: "list", markdown: "- one\n- two", want: "• one • two"},
+		{name: "long text", markdown: strings.Repeat("word ", 50), want: strings.TrimSpace(strings.Repeat("word ", 50))},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := RenderInlineMarkdown(test.markdown, lipgloss.NewStyle()); got != test.want {
+				t.Errorf("rendered = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRenderInlineMarkdownBaseStyle(t *testing.T) {
+	setColorProfile(t, termenv.Ascii)
+	plain := RenderInlineMarkdown("**bold** and `code`", lipgloss.NewStyle())
+
+	setColorProfile(t, termenv.TrueColor)
+	base := lipgloss.NewStyle().Background(lipgloss.Color("#ff0000"))
+	prefix, _, _ := strings.Cut(base.Render("X"), "X")
+	rendered := RenderInlineMarkdown("**bold** and `code`", base)
+	if rendered == plain {
+		t.Fatalf("rendered text %q wasn't styled for the new color profile", rendered)
+	}
+	if !strings.HasPrefix(rendered, prefix) {
+		t.Errorf("rendered text %q doesn't start with the base style %q", rendered, prefix)
+	}
+	// Every reset should be followed by the base style so the background continues
+	parts := strings.Split(rendered, "\x1b[0m")
+	for _, part := range parts[1:] {
+		if !strings.HasPrefix(part, prefix) {
+			t.Errorf("base style isn't re-applied after a reset in %q", rendered)
+			break
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package prompt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aschey/bubbleprompt/history"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type stopMsg struct{}
+
+// runningModel keeps running until the prompt stops it.
+type runningModel struct{}
+
+func (m runningModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m runningModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	return m, nil
+}
+
+func (m runningModel) View() string {
+	return "running\n"
+}
+
+// interruptibleModel stops when it's interrupted if stops is true. Otherwise, it ignores interrupts.
+type interruptibleModel struct {
+	runningModel
+	stops bool
+}
+
+func (m interruptibleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if _, ok := msg.(stopMsg); ok && m.stops {
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m interruptibleModel) Interrupt() tea.Cmd {
+	return func() tea.Msg { return stopMsg{} }
+}
+
+func TestInterrupt(t *testing.T) {
+	tests := []struct {
+		name      string
+		executor  tea.Model
+		search    bool
+		presses   int
+		quit      bool
+		executing bool
+	}{
+		{name: "quit while waiting for input", presses: 1, quit: true},
+		{name: "cancel search", search: true, presses: 1},
+		{name: "quit after cancelling search", search: true, presses: 2, quit: true},
+		{name: "stop executor", executor: runningModel{}, presses: 1},
+		{name: "interruptible executor stops", executor: interruptibleModel{stops: true}, presses: 1},
+		{
+			name:      "interruptible executor keeps running",
+			executor:  interruptibleModel{},
+			presses:   1,
+			executing: true,
+		},
+		{name: "double press quits", executor: interruptibleModel{}, presses: 2, quit: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler := &testHandler{}
+			if test.executor != nil {
+				handler.execute = func(input string, prompt *Model[any]) (tea.Model, error) {
+					return test.executor, nil
+				}
+			}
+			p := newTestPrompt(t, handler, WithHistory[any](history.NewMemoryHistory()))
+			if test.executor != nil {
+				p.submit("run")
+			}
+			if test.search {
+				p.send(keyCtrlR)
+				p.typeText("x")
+			}
+			for i := 0; i < test.presses; i++ {
+				p.send(keyCtrlC)
+			}
+			if p.quit != test.quit {
+				t.Errorf("quit = %v, want %v", p.quit, test.quit)
+			}
+			if test.quit {
+				return
+			}
+			if executing := p.model.modelState == executing; executing != test.executing {
+				t.Errorf("executing = %v, want %v", executing, test.executing)
+			}
+			if !test.executing && !strings.HasPrefix(p.view(), "> ") {
+				t.Errorf("view %q doesn't show the input", p.view())
+			}
+			if test.executor != nil && !strings.Contains(p.output()+p.view(), "running") {
+				t.Errorf("executor output is missing from %q", p.output()+p.view())
+			}
+		})
+	}
+}
+
+func TestInterruptRendersInput(t *testing.T) {
+	handler := &testHandler{execute: func(input string, prompt *Model[any]) (tea.Model, error) {
+		return runningModel{}, nil
+	}}
+	p := newTestPrompt(t, handler)
+	p.submit("run")
+	// The input should be shown right away instead of waiting for the next message
+	model, _ := p.model.Update(keyCtrlC)
+	p.model = model.(Model[any])
+	if !strings.HasPrefix(p.view(), "> ") {
+		t.Errorf("view %q doesn't show the input", p.view())
+	}
+}
@@ -0,0 +1,313 @@
+package prompt
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aschey/bubbleprompt/executor"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ErrJobNotFound is returned when a job ID doesn't match any running background job.
+var ErrJobNotFound = errors.New("job not found")
+
+// Job is an executor that's running in the background while the user types new commands.
+type Job struct {
+	// ID identifies the job. IDs are reused once the job finishes.
+	ID int
+	// Input is the input that started the job.
+	Input string
+	// Model is the executor model for the job.
+	Model tea.Model
+	// Stopped is set when the job was stopped with [KeyMap.Background] and hasn't been resumed yet.
+	Stopped bool
+}
+
+type backgroundModel struct {
+	tea.Model
+}
+
+// RunInBackground marks an executor model to run as a background job.
+// Return the result from [InputHandler.Execute] to keep the prompt interactive while the model runs.
+// The model's output is printed along with a notification once it finishes.
+func RunInBackground(model tea.Model) tea.Model {
+	return backgroundModel{model}
+}
+
+type foregroundModel struct {
+	tea.Model
+	manager *executionManager
+}
+
+// jobMsg is a message returned by an executor's command that should only be sent to that executor.
+// The id is the ID of the execution manager rather than the job ID
+// so the message can be routed before the executor is moved to the background.
+type jobMsg struct {
+	id  int
+	msg tea.Msg
+}
+
+// jobFinishedMsg is sent instead of [tea.QuitMsg] when an executor quits.
+type jobFinishedMsg struct {
+	id     int
+	killed bool
+}
+
+// wrapJobCmd tags the messages from an executor's command so they're only sent to that executor,
+// regardless of whether it's running in the foreground or the background.
+// The execution manager wraps every command as soon as it gets it from the executor.
+func wrapJobCmd(id int, cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		return wrapJobMsg(id, cmd())
+	}
+}
+
+func wrapJobCmds(id int, cmds []tea.Cmd) []tea.Cmd {
+	wrapped := make([]tea.Cmd, len(cmds))
+	for i, cmd := range cmds {
+		wrapped[i] = wrapJobCmd(id, cmd)
+	}
+	return wrapped
+}
+
+func wrapJobMsg(id int, msg tea.Msg) tea.Msg {
+	switch msg := msg.(type) {
+	case nil:
+		return nil
+	case tea.QuitMsg:
+		return jobFinishedMsg{id: id}
+	case tea.BatchMsg:
+		// Batched commands need to be wrapped individually
+		return tea.BatchMsg(wrapJobCmds(id, msg))
+	}
+	if cmds, ok := teaRuntime.sequencedCmds(msg); ok {
+		// Create a new sequence from the wrapped commands so the runtime still runs them in order
+		return tea.Sequence(wrapJobCmds(id, cmds)...)()
+	}
+	if teaRuntime.handles(msg) {
+		return msg
+	}
+	return jobMsg{id: id, msg: msg}
+}
+
+// Jobs returns the jobs that are running in the background.
+func (m Model[T]) Jobs() []Job {
+	jobs := []Job{}
+	for _, job := range m.jobs {
+		jobs = append(jobs, Job{ID: job.jobID, Input: job.input, Model: job.inner, Stopped: job.stopped})
+	}
+	return jobs
+}
+
+// ForegroundJob moves a background job back to the foreground. Stopped jobs are resumed.
+// Return the result from [InputHandler.Execute] to resume showing the job's output.
+func (m *Model[T]) ForegroundJob(id int) (tea.Model, error) {
+	job, err := m.removeJob(id)
+	if err != nil {
+		return nil, err
+	}
+	return foregroundModel{Model: job.inner, manager: job}, nil
+}
+
+// ResumeJob keeps running a job that was stopped with [KeyMap.Background] in the background, like bg in a shell.
+// Jobs that are already running are left as-is.
+func (m *Model[T]) ResumeJob(id int) error {
+	index := m.jobIndex(id)
+	if index < 0 {
+		return fmt.Errorf("%w: %d", ErrJobNotFound, id)
+	}
+	job := m.jobs[index]
+	if !job.stopped {
+		return nil
+	}
+	m.renderer.AddHistory(fmt.Sprintf("[%d] %s &", job.jobID, job.input))
+	m.jobCmds = append(m.jobCmds, m.resumeJob(index))
+	return nil
+}
+
+// KillJob stops a background job.
+// Jobs that implement [executor.Interruptible] are interrupted and keep running until they quit.
+// Other jobs are removed immediately.
+func (m *Model[T]) KillJob(id int) error {
+	index := m.jobIndex(id)
+	if index < 0 {
+		return fmt.Errorf("%w: %d", ErrJobNotFound, id)
+	}
+	if m.jobs[index].stopped {
+		// The job needs to be running to handle the interrupt
+		m.jobCmds = append(m.jobCmds, m.resumeJob(index))
+	}
+	job := m.jobs[index]
+	if cmd, ok := job.interrupt(); ok {
+		m.jobCmds = append(m.jobCmds, cmd)
+	} else {
+		m.jobCmds = append(m.jobCmds, func() tea.Msg { return jobFinishedMsg{id: job.id, killed: true} })
+	}
+	return nil
+}
+
+func (m Model[T]) jobIndex(id int) int {
+	for i, job := range m.jobs {
+		if job.jobID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// managerIndex finds the job for the execution manager ID that's used to tag messages.
+func (m Model[T]) managerIndex(id int) int {
+	for i, job := range m.jobs {
+		if job.id == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (m *Model[T]) removeJob(id int) (*executionManager, error) {
+	index := m.jobIndex(id)
+	if index < 0 {
+		return nil, fmt.Errorf("%w: %d", ErrJobNotFound, id)
+	}
+	job := m.jobs[index]
+	m.jobs = append(m.jobs[:index:index], m.jobs[index+1:]...)
+	return job, nil
+}
+
+func (m *Model[T]) addJob(job *executionManager) {
+	if job.jobID == 0 {
+		// Use the next ID after the highest one that's in use like a shell does
+		job.jobID = 1
+		for _, existing := range m.jobs {
+			job.jobID = max(job.jobID, existing.jobID+1)
+		}
+	}
+	m.jobs = append(m.jobs, job)
+}
+
+func (m *Model[T]) startJob(manager *executionManager) tea.Cmd {
+	m.addJob(manager)
+	m.renderer.AddHistory(fmt.Sprintf("[%d] %s", manager.jobID, manager.input))
+	return tea.Sequence(manager.Init(), func() tea.Msg { return m.size })
+}
+
+// backgroundExecutor stops the running executor and moves it into the job table.
+func (m *Model[T]) backgroundExecutor() tea.Cmd {
+	job := *m.executionManager
+	job.stopped = true
+	m.addJob(&job)
+	m.renderer.AddHistory(fmt.Sprintf("[%d] Stopped %s", job.jobID, job.input))
+	m.interrupted = false
+	m.updateExecutor(nil)
+	cmds := []tea.Cmd{job.stop()}
+	if m.focus {
+		cmds = append(cmds, m.textInput.Focus())
+	}
+	return tea.Batch(cmds...)
+}
+
+// resumeJob continues the job and sends the messages that were held while it was stopped in their original order.
+func (m *Model[T]) resumeJob(index int) tea.Cmd {
+	job := *m.jobs[index]
+	cmd := replayMsgs(job.pending)
+	job.stopped = false
+	job.pending = nil
+	m.jobs[index] = &job
+	// The job didn't receive any size changes while it was stopped
+	return tea.Sequence(job.resume(), cmd, func() tea.Msg { return m.size })
+}
+
+func replayMsgs(msgs []tea.Msg) tea.Cmd {
+	cmds := []tea.Cmd{}
+	for _, msg := range msgs {
+		cmds = append(cmds, func() tea.Msg { return msg })
+	}
+	return tea.Sequence(cmds...)
+}
+
+// holdJobMsg stores a message for a stopped job until it's resumed.
+func (m *Model[T]) holdJobMsg(index int, msg tea.Msg) {
+	job := *m.jobs[index]
+	job.pending = append(job.pending, msg)
+	m.jobs[index] = &job
+}
+
+func (m *Model[T]) finishJob(msg jobFinishedMsg) tea.Cmd {
+	index := m.managerIndex(msg.id)
+	if index < 0 {
+		return nil
+	}
+	job, err := m.removeJob(m.jobs[index].jobID)
+	if err != nil {
+		return nil
+	}
+	if msg.killed {
+		m.renderer.AddHistory(fmt.Sprintf("[%d] Killed %s", job.jobID, job.input))
+		return m.addResult(job.result(ErrJobKilled))
+	}
+	m.renderer.AddHistory(job.View())
+	m.renderer.AddHistory(fmt.Sprintf("[%d] Done %s", job.jobID, job.input))
+	return m.addResult(job.result(nil))
+}
+
+// foregroundJobMsg unwraps messages from a job that was moved to the foreground after they were sent.
+func (m Model[T]) foregroundJobMsg(msg tea.Msg) tea.Msg {
+	if m.modelState != executing {
+		return msg
+	}
+	switch msg := msg.(type) {
+	case jobMsg:
+		if msg.id == m.executionManager.id {
+			return msg.msg
+		}
+	case jobFinishedMsg:
+		if msg.id == m.executionManager.id {
+			return quitAttempted{}
+		}
+	}
+	return msg
+}
+
+// updateJobs sends the message to the background jobs that it applies to.
+func (m *Model[T]) updateJobs(msg tea.Msg) tea.Cmd {
+	cmds := []tea.Cmd{}
+	switch msg := msg.(type) {
+	case jobMsg:
+		index := m.managerIndex(msg.id)
+		switch {
+		case index < 0:
+			// The executor already finished or it's running in the foreground
+		case m.jobs[index].stopped:
+			m.holdJobMsg(index, msg)
+		default:
+			cmds = append(cmds, m.updateJob(index, msg.msg))
+		}
+	case jobFinishedMsg:
+		if index := m.managerIndex(msg.id); index > -1 && m.jobs[index].stopped {
+			m.holdJobMsg(index, msg)
+		} else {
+			cmds = append(cmds, m.finishJob(msg))
+		}
+	case tea.KeyMsg, tea.MouseMsg, executor.ErrorMsg, quitAttempted:
+		// Only meant for the foreground
+	default:
+		for i, job := range m.jobs {
+			if !job.stopped {
+				cmds = append(cmds, m.updateJob(i, msg))
+			}
+		}
+	}
+	cmds = append(cmds, m.jobCmds...)
+	m.jobCmds = nil
+	return tea.Batch(cmds...)
+}
+
+func (m *Model[T]) updateJob(index int, msg tea.Msg) tea.Cmd {
+	job, cmd := m.jobs[index].Update(msg)
+	m.jobs[index] = &job
+	return cmd
+}
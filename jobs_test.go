@@ -0,0 +1,293 @@
+package prompt
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aschey/bubbleprompt/executor"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type countMsg struct{}
+
+// countModel counts the count messages that it receives.
+// If done is set, it sends one to itself when it starts and quits once it's received.
+type countModel struct {
+	done  bool
+	count int
+}
+
+func (m countModel) Init() tea.Cmd {
+	if m.done {
+		return func() tea.Msg { return countMsg{} }
+	}
+	return nil
+}
+
+func (m countModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if _, ok := msg.(countMsg); ok {
+		m.count++
+		if m.done {
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+func (m countModel) View() string {
+	return fmt.Sprintf("count: %d\n", m.count)
+}
+
+// failModel fails as soon as it starts, like an async string model whose work returns an error.
+type failModel struct{}
+
+func (m failModel) Init() tea.Cmd {
+	return func() tea.Msg { return executor.ErrorMsg(errors.New("boom")) }
+}
+
+func (m failModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	return m, nil
+}
+
+func (m failModel) View() string {
+	return ""
+}
+
+func newJobHandler() *testHandler {
+	return &testHandler{execute: func(input string, prompt *Model[any]) (tea.Model, error) {
+		switch input {
+		case "count &":
+			return RunInBackground(countModel{done: true}), nil
+		case "idle &":
+			return RunInBackground(countModel{}), nil
+		case "idle":
+			return countModel{}, nil
+		case "fail":
+			return failModel{}, nil
+		case "bg":
+			return nil, prompt.ResumeJob(1)
+		case "fg":
+			return prompt.ForegroundJob(1)
+		case "kill":
+			return nil, prompt.KillJob(1)
+		}
+		return executor.NewStringModel(input), nil
+	}}
+}
+
+func TestJobs(t *testing.T) {
+	tests := []struct {
+		name string
+		// started is submitted before the other inputs.
+		// Its commands are held back until ctrl+z is pressed so they're still in flight when the executor stops.
+		started string
+		inputs  []string
+		stopped bool
+		idle    int
+		output  string
+		err     error
+	}{
+		{name: "background job", inputs: []string{"idle &", "count &"}, idle: 0, output: "[2] Done count &"},
+		{name: "stopped job holds messages", started: "fail", stopped: true, output: "[1] Stopped fail"},
+		{name: "bg resumes", started: "fail", inputs: []string{"bg"}, output: "[1] fail &", err: errors.New("boom")},
+		{name: "fg resumes", started: "fail", inputs: []string{"fg"}, err: errors.New("boom")},
+		{name: "kill stopped job", started: "idle", inputs: []string{"kill"}, output: "[1] Killed idle", err: ErrJobKilled},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler := newJobHandler()
+			p := newTestPrompt(t, handler)
+			if test.started != "" {
+				p.typeText(test.started)
+				model, cmd := p.model.Update(keyEnter)
+				p.model = model.(Model[any])
+				p.send(tea.KeyMsg{Type: tea.KeyCtrlZ})
+				p.run(cmd)
+			}
+			for _, input := range test.inputs {
+				p.submit(input)
+			}
+
+			jobs := p.model.Jobs()
+			if stopped := len(jobs) > 0 && jobs[0].Stopped; stopped != test.stopped {
+				t.Errorf("stopped = %v, want %v", stopped, test.stopped)
+			}
+			for _, job := range jobs {
+				if model, ok := job.Model.(countModel); ok && model.count != test.idle {
+					t.Errorf("idle job count = %d, want %d", model.count, test.idle)
+				}
+			}
+			if !strings.Contains(p.output(), test.output) {
+				t.Errorf("output %q doesn't contain %q", p.output(), test.output)
+			}
+
+			var jobErr error
+			for _, result := range handler.results {
+				if result.JobID == 1 {
+					jobErr = result.Err
+				}
+			}
+			if fmt.Sprint(jobErr) != fmt.Sprint(test.err) {
+				t.Errorf("job error = %v, want %v", jobErr, test.err)
+			}
+		})
+	}
+}
+
+func countCmd() tea.Msg {
+	return countMsg{}
+}
+
+// batchModel counts the messages from batched and sequenced commands.
+type batchModel struct {
+	countModel
+}
+
+func (m batchModel) Init() tea.Cmd {
+	return tea.Batch(countCmd, tea.Sequence(countCmd, tea.Batch(countCmd, countCmd)))
+}
+
+func (m batchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := m.countModel.Update(msg)
+	m.countModel = model.(countModel)
+	return m, cmd
+}
+
+func TestJobBatchAndSequence(t *testing.T) {
+	handler := &testHandler{execute: func(input string, prompt *Model[any]) (tea.Model, error) {
+		if input == "idle &" {
+			return RunInBackground(countModel{}), nil
+		}
+		return RunInBackground(batchModel{}), nil
+	}}
+	p := newTestPrompt(t, handler)
+	p.submit("idle &")
+	p.submit("batch &")
+
+	jobs := p.model.Jobs()
+	if len(jobs) != 2 {
+		t.Fatalf("jobs = %d, want 2", len(jobs))
+	}
+	if count := jobs[0].Model.(countModel).count; count != 0 {
+		t.Errorf("idle job count = %d, want 0", count)
+	}
+	if count := jobs[1].Model.(batchModel).count; count != 4 {
+		t.Errorf("batch job count = %d, want 4", count)
+	}
+}
+
+func TestWrapJobMsg(t *testing.T) {
+	tests := []struct {
+		name string
+		cmd  tea.Cmd
+		// want is the expected message for each command after unwrapping batches and sequences
+		want     []tea.Msg
+		sequence bool
+	}{
+		{name: "message", cmd: countCmd, want: []tea.Msg{jobMsg{id: 1, msg: countMsg{}}}},
+		{name: "quit", cmd: tea.Quit, want: []tea.Msg{jobFinishedMsg{id: 1}}},
+		{
+			name: "batch",
+			cmd:  tea.Batch(countCmd, tea.Quit),
+			want: []tea.Msg{jobMsg{id: 1, msg: countMsg{}}, jobFinishedMsg{id: 1}},
+		},
+		{
+			name:     "sequence",
+			cmd:      tea.Sequence(countCmd, tea.Quit),
+			want:     []tea.Msg{jobMsg{id: 1, msg: countMsg{}}, jobFinishedMsg{id: 1}},
+			sequence: true,
+		},
+		{name: "window title", cmd: tea.SetWindowTitle("title"), want: []tea.Msg{tea.SetWindowTitle("title")()}},
+		{name: "print", cmd: tea.Println("line"), want: []tea.Msg{tea.Println("line")()}},
+		{name: "alt screen", cmd: tea.EnterAltScreen, want: []tea.Msg{tea.EnterAltScreen()}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			msg := wrapJobCmd(1, test.cmd)()
+			msgs := []tea.Msg{msg}
+			switch wrapped := msg.(type) {
+			case tea.BatchMsg:
+				msgs = nil
+				for _, cmd := range wrapped {
+					msgs = append(msgs, cmd())
+				}
+			default:
+				cmds, ok := teaRuntime.sequencedCmds(msg)
+				if !ok {
+					break
+				}
+				msgs = nil
+				for _, cmd := range cmds {
+					msgs = append(msgs, cmd())
+				}
+			}
+			if _, sequence := teaRuntime.sequencedCmds(msg); sequence != test.sequence {
+				t.Errorf("sequence = %v, want %v", sequence, test.sequence)
+			}
+			if !reflect.DeepEqual(msgs, test.want) {
+				t.Errorf("messages = %#v, want %#v", msgs, test.want)
+			}
+		})
+	}
+}
+
+// stopModel records when the prompt stops and continues it.
+type stopModel struct {
+	countModel
+	signals *[]string
+}
+
+func (m stopModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	return m, nil
+}
+
+func (m stopModel) Stop() tea.Cmd {
+	return func() tea.Msg {
+		*m.signals = append(*m.signals, "stop")
+		return nil
+	}
+}
+
+func (m stopModel) Continue() tea.Cmd {
+	return func() tea.Msg {
+		*m.signals = append(*m.signals, "continue")
+		return nil
+	}
+}
+
+func TestStoppableJob(t *testing.T) {
+	tests := []struct {
+		name    string
+		inputs  []string
+		signals []string
+	}{
+		{name: "stop", signals: []string{"stop"}},
+		{name: "bg", inputs: []string{"bg"}, signals: []string{"stop", "continue"}},
+		{name: "fg", inputs: []string{"fg"}, signals: []string{"stop", "continue"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			signals := []string{}
+			handler := newJobHandler()
+			execute := handler.execute
+			handler.execute = func(input string, prompt *Model[any]) (tea.Model, error) {
+				if input == "stoppable" {
+					return stopModel{signals: &signals}, nil
+				}
+				return execute(input, prompt)
+			}
+			p := newTestPrompt(t, handler)
+			p.submit("stoppable")
+			p.send(tea.KeyMsg{Type: tea.KeyCtrlZ})
+			for _, input := range test.inputs {
+				p.submit(input)
+			}
+			if !reflect.DeepEqual(signals, test.signals) {
+				t.Errorf("signals = %v, want %v", signals, test.signals)
+			}
+		})
+	}
+}
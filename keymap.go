@@ -0,0 +1,83 @@
+package prompt
+
+import (
+	"github.com/aschey/bubbleprompt/renderer"
+	"github.com/aschey/bubbleprompt/suggestion"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+)
+
+// KeyMap defines the key bindings used by the prompt.
+// The bindings can be shown to the user with [github.com/charmbracelet/bubbles/help].
+// Disable a binding with [key.Binding.SetEnabled] to turn off the corresponding behavior.
+type KeyMap struct {
+	// Interrupt stops the running executor or cancels the history search.
+	// While the prompt is waiting for input, or if pressed again before the executor stops,
+	// it shuts down the whole program.
+	Interrupt key.Binding
+	// Background stops the running executor and moves it to the job table so the user can type new commands.
+	// A stopped executor doesn't receive any messages until it's resumed with [Model.ResumeJob] or [Model.ForegroundJob].
+	// Executors that implement [github.com/aschey/bubbleprompt/executor.Stoppable] also pause their work.
+	Background key.Binding
+	// Quit shuts down the program while the prompt is waiting for input.
+	Quit                key.Binding
+	Submit              key.Binding
+	HistoryPrevious     key.Binding
+	HistoryNext         key.Binding
+	HistorySearch       key.Binding
+	CancelHistorySearch key.Binding
+	// AcceptAutosuggestion inserts the autosuggestion shown after the cursor.
+	// Only used when autosuggestions are enabled with [WithAutosuggestions].
+	AcceptAutosuggestion key.Binding
+	// PreviewScrollUp and PreviewScrollDown scroll the selected suggestion's preview.
+	PreviewScrollUp   key.Binding
+	PreviewScrollDown key.Binding
+	// Suggestion contains the keys used to move through the suggestions.
+	// Only applied to suggestion managers that implement [suggestion.KeyMapSetter].
+	Suggestion suggestion.KeyMap
+	// Renderer contains the keys used to scroll the output.
+	// Only applied to renderers that implement [renderer.KeyMapSetter].
+	Renderer renderer.KeyMap
+	// Input contains the keys used to edit the text.
+	// Only applied to inputs that implement [input.KeyMapSetter].
+	Input textinput.KeyMap
+}
+
+// DefaultKeyMap returns the default key bindings.
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Interrupt:           key.NewBinding(key.WithKeys("ctrl+c"), key.WithHelp("ctrl+c", "interrupt/exit")),
+		Background:          key.NewBinding(key.WithKeys("ctrl+z"), key.WithHelp("ctrl+z", "stop")),
+		Quit:                key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "quit")),
+		Submit:              key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "submit")),
+		HistoryPrevious:     key.NewBinding(key.WithKeys("up"), key.WithHelp("↑", "previous entry")),
+		HistoryNext:         key.NewBinding(key.WithKeys("down"), key.WithHelp("↓", "next entry")),
+		HistorySearch:       key.NewBinding(key.WithKeys("ctrl+r"), key.WithHelp("ctrl+r", "search history")),
+		CancelHistorySearch: key.NewBinding(key.WithKeys("esc", "ctrl+g"), key.WithHelp("esc", "cancel search")),
+		AcceptAutosuggestion: key.NewBinding(
+			key.WithKeys("right", "end"),
+			key.WithHelp("→", "accept autosuggestion"),
+		),
+		PreviewScrollUp:   key.NewBinding(key.WithKeys("alt+up"), key.WithHelp("alt+↑", "scroll preview up")),
+		PreviewScrollDown: key.NewBinding(key.WithKeys("alt+down"), key.WithHelp("alt+↓", "scroll preview down")),
+		Suggestion:        suggestion.DefaultKeyMap(),
+		Renderer:          renderer.DefaultKeyMap(),
+		Input:             textinput.DefaultKeyMap,
+	}
+}
+
+// ShortHelp returns the bindings shown in the short help view.
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Submit, k.Suggestion.Complete, k.HistorySearch, k.Quit}
+}
+
+// FullHelp returns the bindings shown in the expanded help view.
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Submit, k.Quit, k.Interrupt, k.Background},
+		{k.Suggestion.Complete, k.Suggestion.Next, k.Suggestion.Previous, k.AcceptAutosuggestion},
+		{k.PreviewScrollUp, k.PreviewScrollDown},
+		{k.HistoryPrevious, k.HistoryNext, k.HistorySearch, k.CancelHistorySearch},
+		{k.Renderer.ScrollUp, k.Renderer.ScrollDown, k.Renderer.PageUp, k.Renderer.PageDown},
+	}
+}
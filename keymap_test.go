@@ -0,0 +1,114 @@
+package prompt
+
+import (
+	"testing"
+
+	"github.com/aschey/bubbleprompt/history"
+	"github.com/aschey/bubbleprompt/suggestion"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var (
+	keyCtrlN = tea.KeyMsg{Type: tea.KeyCtrlN}
+	keyCtrlS = tea.KeyMsg{Type: tea.KeyCtrlS}
+	keyCtrlH = tea.KeyMsg{Type: tea.KeyCtrlH}
+	keyBack  = tea.KeyMsg{Type: tea.KeyBackspace}
+)
+
+func TestKeyMap(t *testing.T) {
+	tests := []struct {
+		name string
+		// keyMap changes the default key map
+		keyMap func(keyMap *KeyMap)
+		opts   []Option[any]
+		input  string
+		keys   []tea.KeyMsg
+		// value is the input's value after the keys are pressed
+		value    string
+		selected string
+		output   string
+	}{
+		{
+			name:   "default submit",
+			input:  "a",
+			keys:   []tea.KeyMsg{keyEnter},
+			output: "> a \na",
+		},
+		{
+			name:   "custom submit",
+			keyMap: func(keyMap *KeyMap) { keyMap.Submit = key.NewBinding(key.WithKeys("ctrl+s")) },
+			input:  "a",
+			keys:   []tea.KeyMsg{keyEnter, keyCtrlS},
+			output: "> a \na",
+		},
+		{
+			name:   "disabled submit",
+			keyMap: func(keyMap *KeyMap) { keyMap.Submit.SetEnabled(false) },
+			input:  "a",
+			keys:   []tea.KeyMsg{keyEnter},
+			value:  "a",
+		},
+		{
+			name:   "disabled history search",
+			keyMap: func(keyMap *KeyMap) { keyMap.HistorySearch.SetEnabled(false) },
+			opts:   []Option[any]{WithHistory[any](history.NewMemoryHistory())},
+			input:  "a",
+			keys:   []tea.KeyMsg{keyCtrlR},
+			value:  "a",
+		},
+		{
+			name:     "custom suggestion key",
+			keyMap:   func(keyMap *KeyMap) { keyMap.Suggestion.Next = key.NewBinding(key.WithKeys("ctrl+n")) },
+			input:    "s",
+			keys:     []tea.KeyMsg{keyCtrlN},
+			value:    "s1",
+			selected: "s1",
+		},
+		{
+			name:   "custom input key",
+			keyMap: func(keyMap *KeyMap) { keyMap.Input.DeleteCharacterBackward = key.NewBinding(key.WithKeys("ctrl+h")) },
+			input:  "ab",
+			keys:   []tea.KeyMsg{keyBack, keyCtrlH},
+			value:  "a",
+		},
+		{
+			name:   "suggestion manager without key map setter",
+			keyMap: func(keyMap *KeyMap) { keyMap.Suggestion.Next = key.NewBinding(key.WithKeys("ctrl+n")) },
+			opts: []Option[any]{func(model *Model[any]) {
+				model.suggestionManager = basicManager{model.suggestionManager}
+			}},
+			input:    "s",
+			keys:     []tea.KeyMsg{keyDown},
+			value:    "s1",
+			selected: "s1",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			keyMap := DefaultKeyMap()
+			if test.keyMap != nil {
+				test.keyMap(&keyMap)
+			}
+			handler := &testHandler{suggestions: []suggestion.Suggestion[any]{{Text: "s1"}, {Text: "s2"}}}
+			p := newTestPrompt(t, handler, append(test.opts, WithKeyMap[any](keyMap))...)
+			p.typeText(test.input)
+			for _, key := range test.keys {
+				p.send(key)
+			}
+			if p.value() != test.value {
+				t.Errorf("value = %q, want %q", p.value(), test.value)
+			}
+			selected := ""
+			if suggestion := p.model.suggestionManager.SelectedSuggestion(); suggestion != nil {
+				selected = suggestion.Text
+			}
+			if selected != test.selected {
+				t.Errorf("selected = %q, want %q", selected, test.selected)
+			}
+			if p.output() != test.output {
+				t.Errorf("output = %q, want %q", p.output(), test.output)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package prompt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aschey/bubbleprompt/input/simpleinput"
+	"github.com/aschey/bubbleprompt/suggestion"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var (
+	keyAltUp = tea.KeyMsg{Type: tea.KeyUp, Alt: true}
+	keyLeft  = tea.KeyMsg{Type: tea.KeyLeft}
+	keyTab   = tea.KeyMsg{Type: tea.KeyTab}
+)
+
+// multilineHandler treats the input as complete once it ends with a semicolon.
+type multilineHandler struct {
+	*testHandler
+}
+
+func (h multilineHandler) Update(msg tea.Msg) (InputHandler[any], tea.Cmd) {
+	_, cmd := h.testHandler.Update(msg)
+	return h, cmd
+}
+
+func (h multilineHandler) IsInputComplete(input string) bool {
+	return strings.HasSuffix(input, ";")
+}
+
+func newMultilineTestPrompt(t *testing.T, handler *testHandler) *testPrompt {
+	t.Helper()
+	textInput := simpleinput.New(simpleinput.WithMultiline[any]())
+	return newTestPromptWithInput(t, multilineHandler{handler}, handler, textInput)
+}
+
+func TestMultilineSubmit(t *testing.T) {
+	handler := &testHandler{}
+	p := newMultilineTestPrompt(t, handler)
+	p.submit("select")
+	if p.value() != "select\n" {
+		t.Errorf("value = %q, want %q", p.value(), "select\n")
+	}
+	if len(handler.results) != 0 {
+		t.Errorf("results = %d, want 0", len(handler.results))
+	}
+	if cursor := p.model.textInput.CursorIndex(); cursor != len("select\n") {
+		t.Errorf("cursor = %d, want %d", cursor, len("select\n"))
+	}
+
+	p.submit("1;")
+	if p.value() != "" {
+		t.Errorf("value = %q, want empty", p.value())
+	}
+	if len(handler.results) != 1 || handler.results[0].Input != "select\n1;" {
+		t.Errorf("results = %+v, want the full input", handler.results)
+	}
+}
+
+func TestMultilineRestoreCursor(t *testing.T) {
+	handler := &testHandler{suggestions: []suggestion.Suggestion[any]{{Text: "abc"}}}
+	p := newMultilineTestPrompt(t, handler)
+	// The second line is wider than the terminal so it would be soft-wrapped
+	long := strings.Repeat("x ", 60)
+	p.submit("ab")
+	p.typeText(long)
+	p.send(keyAltUp, keyLeft)
+	if cursor := p.model.textInput.CursorIndex(); cursor != 1 {
+		t.Fatalf("cursor = %d, want 1", cursor)
+	}
+
+	// Selecting a suggestion changes the text and unselecting it restores what was typed
+	p.send(keyTab)
+	if selected := p.model.suggestionManager.SelectedSuggestion(); selected == nil || selected.Text != "abc" {
+		t.Fatalf("selected = %v, want abc", selected)
+	}
+	p.send(keyUp)
+	if p.value() != "ab\n"+long {
+		t.Errorf("value = %q, want %q", p.value(), "ab\n"+long)
+	}
+	if cursor := p.model.textInput.CursorIndex(); cursor != 1 {
+		t.Errorf("cursor = %d, want 1", cursor)
+	}
+}
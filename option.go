@@ -1,6 +1,10 @@
 package prompt
 
 import (
+	"time"
+
+	"github.com/aschey/bubbleprompt/completer"
+	"github.com/aschey/bubbleprompt/history"
 	"github.com/aschey/bubbleprompt/renderer"
 	"github.com/aschey/bubbleprompt/suggestion"
 )
@@ -13,6 +17,10 @@ func WithFormatters[T any](formatters suggestion.Formatters) Option[T] {
 	}
 }
 
+// WithUnmanagedRenderer prints the output with the terminal's normal scrollback.
+// The renderer doesn't know which row the input is on, so suggestions are always shown below the input
+// and are only limited to the height of the terminal. When the input is close to the bottom of the terminal,
+// showing the suggestions scrolls the output up. Use [WithViewportRenderer] to fit the suggestions around the input.
 func WithUnmanagedRenderer[T any](opts ...renderer.Option) Option[T] {
 	return func(model *Model[T]) {
 		model.renderer = renderer.NewUnmanagedRenderer(opts...)
@@ -42,3 +50,63 @@ func WithFocusOnStart[T any](focusOnStart bool) Option[T] {
 		model.focus = focusOnStart
 	}
 }
+
+// WithHistory enables recalling previously submitted inputs with the up and down keys
+// while no suggestions are shown and searching them with ctrl+r.
+// Submitted inputs are stored in the supplied history.
+func WithHistory[T any](history history.History) Option[T] {
+	return func(model *Model[T]) {
+		model.history = newHistoryNavigator(history)
+	}
+}
+
+// WithHistorySearchFilterer sets the filterer used to match history entries against the query
+// during a reverse history search. Defaults to [completer.FuzzyFilter].
+func WithHistorySearchFilterer[T any](filterer completer.Filterer[T]) Option[T] {
+	return func(model *Model[T]) {
+		model.historyFilterer = filterer
+	}
+}
+
+// WithAutosuggestions shows the most likely completion as dim text after the cursor.
+// The newest matching history entry is preferred, followed by the current suggestion.
+// The text is accepted with the keys in [KeyMap.AcceptAutosuggestion].
+// Only applies to inputs that implement [github.com/aschey/bubbleprompt/input.Autosuggester].
+func WithAutosuggestions[T any]() Option[T] {
+	return func(model *Model[T]) {
+		model.autosuggestions = true
+	}
+}
+
+// WithMaxPreviewSize sets the maximum size of the pane that shows the selected suggestion's preview,
+// including its border. Defaults to [DefaultMaxPreviewWidth] and [DefaultMaxPreviewHeight].
+func WithMaxPreviewSize[T any](width int, height int) Option[T] {
+	return func(model *Model[T]) {
+		model.preview.maxWidth = width
+		model.preview.maxHeight = height
+	}
+}
+
+// WithCompletionDebounce waits for the input to stop changing for the given duration before generating suggestions.
+// This is useful for completers that are expensive to call such as ones that make network requests.
+func WithCompletionDebounce[T any](debounce time.Duration) Option[T] {
+	return func(model *Model[T]) {
+		model.completionDebounce = debounce
+	}
+}
+
+// WithMaxResults sets the number of execution results that are kept by [Model.Results].
+// Defaults to [DefaultMaxResults]. Every result is kept if maxResults is less than 1.
+func WithMaxResults[T any](maxResults int) Option[T] {
+	return func(model *Model[T]) {
+		model.maxResults = maxResults
+	}
+}
+
+// WithKeyMap sets the key bindings used by the prompt.
+// The bindings are also passed to the suggestion manager, the renderer, and the input.
+func WithKeyMap[T any](keyMap KeyMap) Option[T] {
+	return func(model *Model[T]) {
+		model.keyMap = keyMap
+	}
+}
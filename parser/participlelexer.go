@@ -2,6 +2,7 @@ package parser
 
 import (
 	"strings"
+	"unicode/utf8"
 
 	"github.com/alecthomas/participle/v2/lexer"
 	"github.com/aschey/bubbleprompt/input"
@@ -32,6 +33,8 @@ func (p *ParticipleLexer) Lex(inputStr string) ([]input.Token, error) {
 	tokens := make([]input.Token, len(lexerTokens))
 	for i, token := range lexerTokens {
 		tokens[i] = input.TokenFromPos(token.Value, symbols[token.Type], i, token.Pos)
+		// The column resets on each line so use the offset to support input that spans multiple lines
+		tokens[i].Start = utf8.RuneCountInString(inputStr[:token.Pos.Offset])
 	}
 
 	return tokens, nil
@@ -0,0 +1,154 @@
+package prompt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aschey/bubbleprompt/renderer"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+const (
+	// DefaultMaxPreviewWidth is the default maximum width of the preview pane, including its border.
+	DefaultMaxPreviewWidth = 60
+	// DefaultMaxPreviewHeight is the default maximum height of the preview pane, including its border.
+	DefaultMaxPreviewHeight = 10
+)
+
+// The preview is moved below the suggestions if there's less room than this to the right of them
+const minPreviewWidth = 20
+
+// preview holds the content of the selected suggestion's preview.
+type preview struct {
+	// key is the key of the suggestion that's being previewed or nil if there's no preview
+	key       *string
+	content   string
+	offset    int
+	maxWidth  int
+	maxHeight int
+}
+
+func newPreview() preview {
+	return preview{maxWidth: DefaultMaxPreviewWidth, maxHeight: DefaultMaxPreviewHeight}
+}
+
+// updatePreview loads the preview when a different suggestion is selected.
+func (m *Model[T]) updatePreview() {
+	selected := m.suggestionManager.SelectedSuggestion()
+	if m.modelState != completing || selected == nil || selected.Preview == nil {
+		m.preview.key = nil
+		m.preview.content = ""
+		return
+	}
+	if m.preview.key != nil && *m.preview.key == *selected.Key() {
+		return
+	}
+	m.preview.key = selected.Key()
+	m.preview.content = strings.TrimRight(selected.Preview(), "\n")
+	m.preview.offset = 0
+}
+
+func (m Model[T]) shouldScrollPreview(msg tea.KeyMsg) bool {
+	return m.preview.key != nil && key.Matches(msg, m.keyMap.PreviewScrollUp, m.keyMap.PreviewScrollDown)
+}
+
+func (m *Model[T]) scrollPreview(msg tea.KeyMsg) {
+	_, _, width, maxHeight := m.previewPlacement(m.renderCompleting())
+	lines, height := m.previewLines(width, maxHeight)
+	offset := m.preview.offset + 1
+	if key.Matches(msg, m.keyMap.PreviewScrollUp) {
+		offset = m.preview.offset - 1
+	}
+	m.preview.offset = max(min(offset, len(lines)-height), 0)
+}
+
+// previewPlacement returns the position and maximum size of the preview pane.
+// The pane is shown to the right of the suggestions if there's enough room, otherwise it's shown below them.
+// When the suggestions are shown above the input, the pane is aligned with the bottom of the suggestions instead.
+func (m Model[T]) previewPlacement(suggestions string) (int, int, int, int) {
+	suggestionsWidth, suggestionsHeight := lipgloss.Size(suggestions)
+	x := suggestionsWidth + 1
+	if m.size.Width-x >= minPreviewWidth {
+		// Stay within the space that's reserved for the suggestions so the output height doesn't change
+		reservedHeight := m.reservedHeight
+		if m.suggestionsAbove {
+			// The popup can't be any taller than the space above the input
+			reservedHeight = m.popupHeight
+		}
+		height := min(m.preview.maxHeight, max(suggestionsHeight, reservedHeight))
+		return x, 0, min(m.preview.maxWidth, m.size.Width-x), height
+	}
+	if m.suggestionsAbove {
+		// There's no room for the preview below a popup
+		return 0, 0, 0, 0
+	}
+	x = max(min(m.SuggestionOffset(), m.size.Width-minPreviewWidth), 0)
+	return x, suggestionsHeight, min(m.preview.maxWidth, m.size.Width-x), m.preview.maxHeight
+}
+
+// previewLines returns the preview wrapped to fit in a pane with the given size
+// and the number of lines that can be shown at once.
+func (m Model[T]) previewLines(width int, maxHeight int) ([]string, int) {
+	pane := m.suggestionManager.Formatters().Preview.Pane
+	contentWidth := max(width-pane.GetHorizontalFrameSize(), 1)
+	lines := strings.Split(ansi.Wrap(m.preview.content, contentWidth, ""), "\n")
+	height := max(min(len(lines), maxHeight-pane.GetVerticalFrameSize()), 1)
+	if len(lines) > height {
+		// Leave room for the status line
+		height = max(height-1, 1)
+	}
+	return lines, height
+}
+
+func (m Model[T]) renderPreview(width int, maxHeight int) string {
+	formatter := m.suggestionManager.Formatters().Preview
+	lines, height := m.previewLines(width, maxHeight)
+	offset := max(min(m.preview.offset, len(lines)-height), 0)
+	visible := lines[offset : offset+height]
+	contentWidth := max(width-formatter.Pane.GetHorizontalFrameSize(), 1)
+	if len(lines) > height {
+		status := fmt.Sprintf(
+			"lines %d-%d of %d • %s/%s scroll",
+			offset+1,
+			offset+height,
+			len(lines),
+			m.keyMap.PreviewScrollUp.Help().Key,
+			m.keyMap.PreviewScrollDown.Help().Key,
+		)
+		visible = append(visible, formatter.Status.Render(ansi.Truncate(status, contentWidth, "…")))
+	}
+	return formatter.Pane.
+		Width(contentWidth + formatter.Pane.GetHorizontalPadding()).
+		Render(strings.Join(visible, "\n"))
+}
+
+// placePreview draws the preview pane on top of the body next to the suggestions.
+func (m Model[T]) placePreview(suggestions string, body string) string {
+	if m.preview.key == nil || m.size.Width <= 0 {
+		return body
+	}
+	x, y, width, maxHeight := m.previewPlacement(suggestions)
+	if width <= 0 {
+		return body
+	}
+	pane := m.renderPreview(width, maxHeight)
+
+	lines := strings.Split(body, "\n")
+	if m.suggestionsAbove {
+		// Grow the popup upwards so it stays next to the input
+		y = lipgloss.Height(suggestions) - lipgloss.Height(pane)
+		if y < 0 {
+			lines = append(make([]string, -y), lines...)
+			y = 0
+		}
+	}
+	for len(lines) < y+lipgloss.Height(pane) {
+		lines = append(lines, "")
+	}
+	// The overlay is only placed if the body is larger than the pane, so extend the first line to the end of the pane
+	lines[0] += strings.Repeat(" ", max(x+lipgloss.Width(pane)-lipgloss.Width(lines[0]), 0))
+	return renderer.PlaceOverlay(x, y, pane, strings.Join(lines, "\n"))
+}
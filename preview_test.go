@@ -0,0 +1,142 @@
+package prompt
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/aschey/bubbleprompt/suggestion"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var keyAltDown = tea.KeyMsg{Type: tea.KeyDown, Alt: true}
+
+func TestPreviewLoad(t *testing.T) {
+	calls := 0
+	handler := &testHandler{suggestions: []suggestion.Suggestion[any]{
+		{Text: "one", Preview: func() string {
+			calls++
+			return "first preview\n\n"
+		}},
+		{Text: "two"},
+	}}
+	p := newTestPrompt(t, handler)
+	p.typeText("o")
+	if calls != 0 {
+		t.Errorf("expected the preview to only load once selected, got %d calls", calls)
+	}
+
+	p.send(keyDown)
+	if p.model.preview.content != "first preview" {
+		t.Errorf("expected the trailing newlines to be trimmed, got %q", p.model.preview.content)
+	}
+	if !strings.Contains(p.view(), "first preview") {
+		t.Errorf("expected the preview in the view, got %q", p.view())
+	}
+
+	// The preview is only loaded again when a different suggestion is selected
+	p.send(tea.WindowSizeMsg{Width: 80, Height: 20})
+	if calls != 1 {
+		t.Errorf("expected one call, got %d", calls)
+	}
+
+	p.send(keyDown)
+	if p.model.preview.key != nil || strings.Contains(p.view(), "first preview") {
+		t.Errorf("expected the preview to be removed once nothing is selected, got %q", p.view())
+	}
+}
+
+func TestPreviewScroll(t *testing.T) {
+	lines := []string{}
+	for i := range 20 {
+		lines = append(lines, fmt.Sprintf("line %d", i+1))
+	}
+	handler := &testHandler{suggestions: []suggestion.Suggestion[any]{
+		{Text: "one", Preview: func() string { return strings.Join(lines, "\n") }},
+	}}
+	p := newTestPrompt(t, handler)
+	p.typeText("o")
+	p.send(keyDown)
+
+	// The pane is as tall as the space that's reserved for the suggestions, so 3 lines fit above the status line
+	tests := []struct {
+		name   string
+		keys   []tea.KeyMsg
+		status string
+	}{
+		{name: "start", status: "lines 1-3 of 20"},
+		{name: "up at the start", keys: []tea.KeyMsg{keyAltUp}, status: "lines 1-3 of 20"},
+		{name: "down", keys: []tea.KeyMsg{keyAltDown, keyAltDown}, status: "lines 3-5 of 20"},
+		{name: "up", keys: []tea.KeyMsg{keyAltUp}, status: "lines 2-4 of 20"},
+		{name: "down past the end", keys: slices.Repeat([]tea.KeyMsg{keyAltDown}, 30), status: "lines 18-20 of 20"},
+	}
+	for _, test := range tests {
+		for _, key := range test.keys {
+			p.send(key)
+		}
+		if view := p.view(); !strings.Contains(view, test.status) {
+			t.Errorf("%s: expected %q in the view, got %q", test.name, test.status, view)
+		}
+	}
+	if p.value() != "one" {
+		t.Errorf("expected scrolling to leave the selection alone, got %q", p.value())
+	}
+}
+
+func TestPreviewPlacement(t *testing.T) {
+	// 10 columns wide and 2 rows tall
+	suggestions := "0123456789\n0123456789"
+	tests := []struct {
+		name             string
+		width            int
+		suggestionsAbove bool
+		popupHeight      int
+		expected         [4]int
+	}{
+		{name: "right", width: 80, expected: [4]int{11, 0, 60, 6}},
+		{name: "right limited by the terminal", width: 40, expected: [4]int{11, 0, 29, 6}},
+		{name: "right of a popup", width: 80, suggestionsAbove: true, popupHeight: 4, expected: [4]int{11, 0, 60, 4}},
+		{name: "below", width: 21, expected: [4]int{1, 2, 20, 10}},
+		{name: "no room below a popup", width: 21, suggestionsAbove: true, popupHeight: 4, expected: [4]int{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := newTestPrompt(t, &testHandler{})
+			p.model.size.Width = test.width
+			p.model.reservedHeight = 6
+			p.model.suggestionsAbove = test.suggestionsAbove
+			p.model.popupHeight = test.popupHeight
+			x, y, width, height := p.model.previewPlacement(suggestions)
+			if got := [4]int{x, y, width, height}; got != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestPreviewLines(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		maxHeight int
+		lines     []string
+		height    int
+	}{
+		{name: "wrapped", content: "aaaa bbbb cccc", maxHeight: 10, lines: []string{"aaaa", "bbbb", "cccc"}, height: 3},
+		{name: "status line", content: "aaaa bbbb cccc", maxHeight: 4, lines: []string{"aaaa", "bbbb", "cccc"}, height: 1},
+		{name: "at least one line", content: "a\nb", maxHeight: 0, lines: []string{"a", "b"}, height: 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := newTestPrompt(t, &testHandler{})
+			p.model.preview.content = test.content
+			// The pane's border and padding leave 6 columns for the content
+			lines, height := p.model.previewLines(10, test.maxHeight)
+			if !reflect.DeepEqual(lines, test.lines) || height != test.height {
+				t.Errorf("expected %q with height %d, got %q with height %d", test.lines, test.height, lines, height)
+			}
+		})
+	}
+}
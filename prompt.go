@@ -1,6 +1,11 @@
 package prompt
 
 import (
+	"context"
+	"time"
+
+	"github.com/aschey/bubbleprompt/completer"
+	"github.com/aschey/bubbleprompt/history"
 	"github.com/aschey/bubbleprompt/input"
 	"github.com/aschey/bubbleprompt/renderer"
 	"github.com/aschey/bubbleprompt/suggestion"
@@ -17,6 +22,7 @@ type modelState int
 const (
 	completing modelState = iota
 	executing
+	searching
 )
 
 type InputHandler[T any] interface {
@@ -26,20 +32,57 @@ type InputHandler[T any] interface {
 	Complete(prompt Model[T]) ([]suggestion.Suggestion[T], error)
 }
 
+// ContextCompleter can optionally be implemented by an [InputHandler] to receive a context
+// when generating suggestions. If implemented, it's called instead of [InputHandler.Complete].
+// The context is cancelled as soon as a newer completion starts so slow completers can stop early.
+type ContextCompleter[T any] interface {
+	CompleteContext(ctx context.Context, prompt Model[T]) ([]suggestion.Suggestion[T], error)
+}
+
+// MultilineInputHandler can optionally be implemented by an [InputHandler] to allow the input to span multiple lines.
+// This requires an input that supports multiple lines such as one created with
+// [github.com/aschey/bubbleprompt/input/lexerinput.WithMultiline].
+type MultilineInputHandler interface {
+	// IsInputComplete reports whether the input is ready to be executed.
+	// If it returns false, submitting the input inserts a newline instead of executing it.
+	IsInputComplete(input string) bool
+}
+
 type Model[T any] struct {
 	suggestionManager       suggestion.Manager[T]
 	inputHandler            InputHandler[T]
 	textInput               input.Input[T]
 	renderer                renderer.Renderer
 	executionManager        *executionManager
+	history                 historyNavigator
+	historySearch           *historySearch[T]
+	historyFilterer         completer.Filterer[T]
+	keyMap                  KeyMap
 	modelState              modelState
 	lastTypedCursorPosition int
+	lastTypedCursorIndex    int
 	typedRunes              []rune
 	ready                   bool
 	size                    tea.WindowSizeMsg
 	sequenceNumber          int
+	cancelCompletion        context.CancelFunc
+	completionDebounce      time.Duration
+	interrupted             bool
+	jobs                    []*executionManager
+	jobCmds                 []tea.Cmd
+	results                 []ExecutionResult
+	maxResults              int
 	focus                   bool
-	err                     error
+	autosuggestions         bool
+	autosuggestion          string
+	preview                 preview
+	// suggestionsAbove is true when the suggestions are shown above the input because there isn't room below it
+	suggestionsAbove bool
+	// reservedHeight is the number of lines that are reserved below the input for the suggestions
+	reservedHeight int
+	// popupHeight is the number of lines above the input that are available to the suggestions popup
+	popupHeight int
+	err         error
 }
 
 func New[T any](
@@ -53,15 +96,36 @@ func New[T any](
 		textInput:         textInput,
 		focus:             true,
 		renderer:          renderer.NewUnmanagedRenderer(),
+		keyMap:            DefaultKeyMap(),
+		maxResults:        DefaultMaxResults,
+		preview:           newPreview(),
 	}
 
 	for _, opt := range opts {
 		opt(&model)
 	}
+	// Apply the key map after all options are set in case the suggestion manager or renderer was replaced
+	model.applyKeyMap()
 
 	return model
 }
 
+func (m *Model[T]) applyKeyMap() {
+	if keyMapSetter, ok := m.suggestionManager.(suggestion.KeyMapSetter); ok {
+		keyMapSetter.SetKeyMap(m.keyMap.Suggestion)
+	}
+	m.applyRendererKeyMap()
+	if keyMapSetter, ok := m.textInput.(input.KeyMapSetter); ok {
+		keyMapSetter.SetKeyMap(m.keyMap.Input)
+	}
+}
+
+func (m *Model[T]) applyRendererKeyMap() {
+	if keyMapSetter, ok := m.renderer.(renderer.KeyMapSetter); ok {
+		keyMapSetter.SetKeyMap(m.keyMap.Renderer)
+	}
+}
+
 func (m *Model[T]) SuggestionManager() suggestion.Manager[T] {
 	return m.suggestionManager
 }
@@ -70,10 +134,20 @@ func (m Model[T]) TextInput() input.Input[T] {
 	return m.textInput
 }
 
+// KeyMap returns the key bindings used by the prompt.
+func (m Model[T]) KeyMap() KeyMap {
+	return m.keyMap
+}
+
 func (m Model[T]) Renderer() renderer.Renderer {
 	return m.renderer
 }
 
+// History returns the history of submitted inputs or nil if history is not enabled.
+func (m Model[T]) History() history.History {
+	return m.history.history
+}
+
 type rendererMsg struct {
 	renderer      renderer.Renderer
 	retainHistory bool
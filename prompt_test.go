@@ -0,0 +1,178 @@
+package prompt
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aschey/bubbleprompt/completer"
+	"github.com/aschey/bubbleprompt/executor"
+	"github.com/aschey/bubbleprompt/input"
+	"github.com/aschey/bubbleprompt/input/simpleinput"
+	"github.com/aschey/bubbleprompt/suggestion"
+	"github.com/charmbracelet/bubbles/cursor"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Commands that take longer than this, such as cursor blinks, are dropped
+const testCmdTimeout = 50 * time.Millisecond
+
+// Guards against commands that keep producing messages forever
+const maxTestUpdates = 10000
+
+type testHandler struct {
+	suggestions []suggestion.Suggestion[any]
+	execute     func(input string, prompt *Model[any]) (tea.Model, error)
+	results     []ExecutionResult
+}
+
+func (h *testHandler) Init() tea.Cmd {
+	return nil
+}
+
+func (h *testHandler) Update(msg tea.Msg) (InputHandler[any], tea.Cmd) {
+	if msg, ok := msg.(ExecutionResultMsg); ok {
+		h.results = append(h.results, msg.Result)
+	}
+	return h, nil
+}
+
+func (h *testHandler) Execute(input string, prompt *Model[any]) (tea.Model, error) {
+	if h.execute != nil {
+		return h.execute(input, prompt)
+	}
+	return executor.NewStringModel(input), nil
+}
+
+func (h *testHandler) Complete(prompt Model[any]) ([]suggestion.Suggestion[any], error) {
+	return completer.NewPrefixFilter[any]().Filter(prompt.TextInput().CurrentTokenRoundDown().Value, h.suggestions), nil
+}
+
+// testPrompt drives a prompt the same way a running program would by feeding the messages from each command
+// back into the model.
+type testPrompt struct {
+	t       *testing.T
+	model   Model[any]
+	handler *testHandler
+	quit    bool
+	updates int
+}
+
+func newTestPrompt(t *testing.T, handler *testHandler, opts ...Option[any]) *testPrompt {
+	t.Helper()
+	return newTestPromptWithInput(t, handler, handler, simpleinput.New[any](), opts...)
+}
+
+// newTestPromptWithInput creates a test prompt that uses the given input and input handler.
+// The test handler still records the execution results if inputHandler wraps it.
+func newTestPromptWithInput(
+	t *testing.T,
+	inputHandler InputHandler[any],
+	handler *testHandler,
+	textInput input.Input[any],
+	opts ...Option[any],
+) *testPrompt {
+	t.Helper()
+	shutdown = false
+	t.Cleanup(func() { shutdown = false })
+	// Blinking sends a message on a timer which would slow down every key press
+	textInput.SetCursorMode(cursor.CursorStatic)
+	p := &testPrompt{t: t, model: New[any](inputHandler, textInput, opts...), handler: handler}
+	p.run(p.model.Init())
+	p.send(tea.WindowSizeMsg{Width: 80, Height: 20})
+	return p
+}
+
+func (p *testPrompt) send(msgs ...tea.Msg) {
+	p.t.Helper()
+	for _, msg := range msgs {
+		p.update(msg)
+	}
+}
+
+// typeText types each rune of the text separately.
+func (p *testPrompt) typeText(text string) {
+	p.t.Helper()
+	for _, r := range text {
+		p.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+// submit types the text and presses enter.
+func (p *testPrompt) submit(text string) {
+	p.t.Helper()
+	p.typeText(text)
+	p.send(tea.KeyMsg{Type: tea.KeyEnter})
+}
+
+func (p *testPrompt) update(msg tea.Msg) {
+	p.t.Helper()
+	if p.quit {
+		return
+	}
+	p.updates++
+	if p.updates > maxTestUpdates {
+		p.t.Fatal("too many updates")
+	}
+	model, cmd := p.model.Update(MsgFilter(p.model, msg))
+	p.model = model.(Model[any])
+	p.run(cmd)
+}
+
+func (p *testPrompt) run(cmd tea.Cmd) {
+	p.t.Helper()
+	if cmd == nil {
+		return
+	}
+	result := make(chan tea.Msg, 1)
+	go func() { result <- cmd() }()
+	var msg tea.Msg
+	select {
+	case msg = <-result:
+	case <-time.After(testCmdTimeout):
+		return
+	}
+
+	// The program filters messages before checking whether it should quit
+	switch msg := MsgFilter(p.model, msg).(type) {
+	case nil:
+	case tea.QuitMsg:
+		p.quit = true
+	case tea.BatchMsg:
+		for _, cmd := range msg {
+			p.run(cmd)
+		}
+	default:
+		// Sequences use an unexported type
+		value := reflect.ValueOf(msg)
+		if value.Kind() == reflect.Slice && value.Type().Elem() == reflect.TypeOf(tea.Cmd(nil)) {
+			for i := 0; i < value.Len(); i++ {
+				cmd, _ := value.Index(i).Interface().(tea.Cmd)
+				p.run(cmd)
+			}
+			return
+		}
+		p.update(msg)
+	}
+}
+
+func (p *testPrompt) view() string {
+	return ansi.Strip(p.model.View())
+}
+
+// output returns everything that was printed above the input.
+func (p *testPrompt) output() string {
+	return ansi.Strip(p.model.renderer.GetHistory())
+}
+
+func (p *testPrompt) value() string {
+	return p.model.textInput.Value()
+}
+
+var (
+	keyUp    = tea.KeyMsg{Type: tea.KeyUp}
+	keyDown  = tea.KeyMsg{Type: tea.KeyDown}
+	keyEnter = tea.KeyMsg{Type: tea.KeyEnter}
+	keyCtrlC = tea.KeyMsg{Type: tea.KeyCtrlC}
+)
@@ -5,6 +5,8 @@ import (
 
 	"github.com/aschey/bubbleprompt/input"
 	"github.com/aschey/bubbleprompt/internal"
+	"github.com/aschey/bubbleprompt/renderer"
+	"github.com/aschey/bubbleprompt/suggestion"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/mattn/go-runewidth"
 )
@@ -43,6 +45,8 @@ func (m Model[T]) renderInput() string {
 	case completing:
 		// If an item is selected, parse out the text portion and apply formatting
 		return internal.TrimNewline(m.textInput.View(input.Interactive))
+	case searching:
+		return m.renderHistorySearchInput()
 	default:
 		return ""
 	}
@@ -50,6 +54,7 @@ func (m Model[T]) renderInput() string {
 
 func (m Model[T]) renderBody() string {
 	lines := ""
+	suggestions := ""
 	contentHeight := 0
 	switch m.modelState {
 	case executing:
@@ -60,20 +65,91 @@ func (m Model[T]) renderBody() string {
 		contentHeight = internal.CountNewlines(lines) + 1
 
 	case completing:
-		contentHeight = len(m.suggestionManager.Suggestions())
+		if m.suggestionsAbove {
+			// The suggestions are shown in a popup above the input so nothing needs to be reserved below it
+			return ""
+		}
+		lines = m.renderCompleting()
+		suggestions = lines
+		if len(m.suggestionManager.Suggestions()) > 0 {
+			// Suggestion managers can show any number of suggestions on each line and may add headers,
+			// so count the rendered rows instead of the suggestions
+			contentHeight = lipgloss.Height(lines) -
+				m.suggestionManager.Formatters().Suggestions.GetVerticalFrameSize()
+		}
 		if contentHeight < 1 {
 			// Always add at least one empty line
 			contentHeight = 1
 		}
-		lines = m.renderCompleting()
+		if m.suggestionManager.Error() != nil {
+			// Errors can span multiple lines
+			contentHeight = internal.CountNewlines(lines) + 1
+		}
+
+	case searching:
+		contentHeight = len(m.historySearch.manager.Suggestions())
+		if contentHeight < 1 {
+			contentHeight = 1
+		}
+		lines = m.renderHistorySearchSuggestions()
 	}
 
 	// Reserve height for the max number of suggestions so the output height stays consistent
-	extraHeight := m.suggestionManager.MaxSuggestions() - contentHeight
+	extraHeight := m.reservedHeight - contentHeight
 	if extraHeight > 0 {
 		lines += strings.Repeat("\n", extraHeight)
 	}
+	if m.modelState == completing {
+		lines = m.placePreview(suggestions, lines)
+	}
 
 	ret := lipgloss.JoinVertical(lipgloss.Left, lines)
 	return ret
 }
+
+// renderPopup renders the suggestions that are shown above the input when there isn't enough room below it.
+func (m Model[T]) renderPopup() string {
+	if m.modelState != completing || !m.suggestionsAbove {
+		return ""
+	}
+	suggestions := m.renderCompleting()
+	return m.placePreview(suggestions, suggestions)
+}
+
+// updateSuggestionPlacement decides where the suggestions are shown based on the space that's available
+// around the input. They're shown below the input if they fit, otherwise they're shown in whichever direction
+// has more room and the number of visible suggestions is reduced to fit.
+func (m *Model[T]) updateSuggestionPlacement() {
+	maxSuggestions := m.suggestionManager.MaxSuggestions()
+	m.suggestionsAbove = false
+	m.reservedHeight = maxSuggestions
+	m.popupHeight = 0
+
+	// Suggestions are always shown below the input at their full height unless the renderer knows how much room
+	// there is and the suggestion manager can shrink to fit
+	heightSetter, canShrink := m.suggestionManager.(suggestion.AvailableHeightSetter)
+	if !canShrink {
+		return
+	}
+	spaceReporter, ok := m.renderer.(renderer.SpaceReporter)
+	if m.modelState != completing || !ok {
+		heightSetter.SetAvailableHeight(0)
+		return
+	}
+
+	frameHeight := m.suggestionManager.Formatters().Suggestions.GetVerticalFrameSize()
+	above, below := spaceReporter.AvailableSpace()
+	_, canPopup := m.renderer.(renderer.PopupSetter)
+	switch {
+	case below >= maxSuggestions+frameHeight:
+		heightSetter.SetAvailableHeight(0)
+	case canPopup && above > below:
+		m.suggestionsAbove = true
+		m.reservedHeight = 0
+		m.popupHeight = above
+		heightSetter.SetAvailableHeight(max(above-frameHeight, 1))
+	default:
+		m.reservedHeight = max(below-frameHeight, 1)
+		heightSetter.SetAvailableHeight(m.reservedHeight)
+	}
+}
@@ -0,0 +1,122 @@
+package prompt
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/aschey/bubbleprompt/renderer"
+	"github.com/aschey/bubbleprompt/suggestion"
+	"github.com/aschey/bubbleprompt/suggestion/grid"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// basicRenderer only implements the methods that are required by the renderer interface.
+type basicRenderer struct {
+	renderer.Renderer
+}
+
+func (r basicRenderer) Update(msg tea.Msg) (renderer.Renderer, tea.Cmd) {
+	_, cmd := r.Renderer.Update(msg)
+	return r, cmd
+}
+
+// spaceRenderer reports the available space but can't show popups.
+type spaceRenderer struct {
+	renderer.Renderer
+}
+
+func (r spaceRenderer) Update(msg tea.Msg) (renderer.Renderer, tea.Cmd) {
+	_, cmd := r.Renderer.Update(msg)
+	return r, cmd
+}
+
+func (r spaceRenderer) AvailableSpace() (int, int) {
+	return r.Renderer.(renderer.SpaceReporter).AvailableSpace()
+}
+
+// basicManager only implements the methods that are required by the suggestion manager interface.
+type basicManager struct {
+	suggestion.Manager[any]
+}
+
+func TestSuggestionPlacement(t *testing.T) {
+	tests := []struct {
+		name     string
+		renderer renderer.Renderer
+		// basicManager wraps the suggestion manager so it can't limit its height
+		basicManager bool
+		height       int
+		// history is the number of commands that are submitted before checking the placement
+		history  int
+		above    bool
+		reserved int
+	}{
+		{name: "viewport fits below", renderer: renderer.NewViewportRenderer(), height: 20, reserved: 6},
+		{name: "viewport shrinks below", renderer: renderer.NewViewportRenderer(), height: 6, reserved: 5},
+		{name: "viewport flips above", renderer: renderer.NewViewportRenderer(), height: 8, history: 10, above: true},
+		{name: "unmanaged fits below", renderer: renderer.NewUnmanagedRenderer(), height: 20, reserved: 6},
+		{name: "unmanaged never flips", renderer: renderer.NewUnmanagedRenderer(), height: 6, history: 10, reserved: 5},
+		{
+			name:     "renderer without available space",
+			renderer: basicRenderer{renderer.NewViewportRenderer()},
+			height:   6,
+			history:  10,
+			reserved: 6,
+		},
+		{
+			name:     "renderer without popups",
+			renderer: spaceRenderer{renderer.NewViewportRenderer()},
+			height:   8,
+			history:  10,
+			reserved: 1,
+		},
+		{
+			name:         "manager without available height",
+			renderer:     renderer.NewViewportRenderer(),
+			basicManager: true,
+			height:       8,
+			history:      10,
+			reserved:     6,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler := &testHandler{}
+			for i := range 10 {
+				handler.suggestions = append(handler.suggestions, suggestion.Suggestion[any]{Text: fmt.Sprintf("s%d", i)})
+			}
+			p := newTestPrompt(t, handler, WithRenderer[any](test.renderer))
+			if test.basicManager {
+				p.model.suggestionManager = basicManager{p.model.suggestionManager}
+			}
+			for i := range test.history {
+				p.submit(fmt.Sprintf("s%d", i))
+			}
+			p.send(tea.WindowSizeMsg{Width: 80, Height: test.height})
+			p.typeText("s")
+			if p.model.suggestionsAbove != test.above {
+				t.Errorf("suggestions above = %t, want %t", p.model.suggestionsAbove, test.above)
+			}
+			if p.model.reservedHeight != test.reserved {
+				t.Errorf("reserved height = %d, want %d", p.model.reservedHeight, test.reserved)
+			}
+		})
+	}
+}
+
+func TestGridSizeWhileBlurred(t *testing.T) {
+	handler := &testHandler{suggestions: []suggestion.Suggestion[any]{{Text: "one"}, {Text: "two"}, {Text: "three"}}}
+	withGrid := func(model *Model[any]) {
+		model.suggestionManager = grid.New(model.textInput)
+	}
+	// The window size is sent while the prompt isn't focused
+	p := newTestPrompt(t, handler, WithFocusOnStart[any](false), withGrid)
+	p.run(Focus())
+	p.typeText("t")
+
+	view := p.view()
+	if !regexp.MustCompile(`two +three`).MatchString(view) {
+		t.Errorf("expected suggestions in the same row, got %q", view)
+	}
+}
@@ -0,0 +1,25 @@
+package renderer
+
+import "github.com/charmbracelet/bubbles/key"
+
+// KeyMap defines the keys used to scroll through the output of renderers that support scrolling.
+type KeyMap struct {
+	ScrollUp   key.Binding
+	ScrollDown key.Binding
+	PageUp     key.Binding
+	PageDown   key.Binding
+}
+
+// DefaultKeyMap returns the default renderer key bindings.
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		ScrollUp:   key.NewBinding(key.WithKeys("ctrl+up"), key.WithHelp("ctrl+↑", "scroll up")),
+		ScrollDown: key.NewBinding(key.WithKeys("ctrl+down"), key.WithHelp("ctrl+↓", "scroll down")),
+		PageUp:     key.NewBinding(key.WithKeys("pgup"), key.WithHelp("pgup", "page up")),
+		PageDown:   key.NewBinding(key.WithKeys("pgdown"), key.WithHelp("pgdn", "page down")),
+	}
+}
+
+func (k KeyMap) bindings() []key.Binding {
+	return []key.Binding{k.ScrollUp, k.ScrollDown, k.PageUp, k.PageDown}
+}
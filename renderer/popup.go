@@ -0,0 +1,32 @@
+package renderer
+
+import (
+	"strings"
+
+	"github.com/aschey/bubbleprompt/internal"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/ansi"
+)
+
+// placePopup draws the popup on top of the last lines of the output.
+// Empty lines are added above the output if it's shorter than the popup.
+// Unlike PlaceOverlay, leading whitespace in the popup hides the output underneath it
+// so the popup's lines stay aligned with the input.
+func placePopup(output string, popup string) string {
+	if popup == "" {
+		return output
+	}
+	lines := []string{}
+	if output != "" {
+		lines = strings.Split(internal.TrimNewline(output), "\n")
+	}
+	popupHeight := lipgloss.Height(popup)
+	for len(lines) < popupHeight {
+		lines = append([]string{""}, lines...)
+	}
+	start := len(lines) - popupHeight
+	for i, popupLine := range strings.Split(popup, "\n") {
+		lines[start+i] = popupLine + cutLeft(lines[start+i], ansi.PrintableRuneWidth(popupLine))
+	}
+	return internal.AddNewlineIfMissing(strings.Join(lines, "\n"))
+}
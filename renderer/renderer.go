@@ -17,3 +17,22 @@ type Renderer interface {
 	GetHistory() string
 	SetHistory(history string) tea.Cmd
 }
+
+// SpaceReporter is implemented by renderers that know how much room there is around the input.
+// Renderers that don't control where the input is drawn, such as the [UnmanagedRenderer], can only estimate it.
+type SpaceReporter interface {
+	// AvailableSpace returns the number of lines that can be shown above and below the input
+	// without moving the input.
+	AvailableSpace() (above int, below int)
+}
+
+// PopupSetter is implemented by renderers that can draw on top of the output directly above the input.
+type PopupSetter interface {
+	// SetPopup sets content that's drawn above the input, such as suggestions that don't fit below it.
+	SetPopup(popup string)
+}
+
+// KeyMapSetter is implemented by renderers that allow the keys used to scroll the output to be customized.
+type KeyMapSetter interface {
+	SetKeyMap(keyMap KeyMap)
+}
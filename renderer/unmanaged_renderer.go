@@ -1,13 +1,17 @@
 package renderer
 
 import (
+	"math"
+
 	"github.com/aschey/bubbleprompt/internal"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
 type UnmanagedRenderer struct {
 	input          string
 	body           string
+	height         int
 	currentHistory string
 	totalHistory   string
 	settings       rendererSettings
@@ -29,9 +33,16 @@ func (u *UnmanagedRenderer) View() string {
 	return u.input + "\n" + u.body
 }
 
-func (u *UnmanagedRenderer) Initialize(msg tea.WindowSizeMsg) {}
+func (u *UnmanagedRenderer) Initialize(msg tea.WindowSizeMsg) {
+	u.SetSize(msg)
+}
+
+func (u *UnmanagedRenderer) SetSize(msg tea.WindowSizeMsg) {
+	u.height = msg.Height - u.settings.heightOffset
+}
 
-func (u *UnmanagedRenderer) SetSize(msg tea.WindowSizeMsg) {}
+// SetKeyMap is a no-op because the terminal handles scrolling for unmanaged output.
+func (u *UnmanagedRenderer) SetKeyMap(keyMap KeyMap) {}
 
 func (u *UnmanagedRenderer) Update(msg tea.Msg) (Renderer, tea.Cmd) {
 	return u, nil
@@ -45,6 +56,20 @@ func (u *UnmanagedRenderer) SetBody(body string) {
 	u.body = body
 }
 
+// AvailableSpace is part of the [SpaceReporter] interface.
+// The terminal manages the output so the renderer doesn't know which row the input is on.
+// It assumes the input is on the first line after the height offset, like it is when the prompt is drawn at the
+// top of the screen. Otherwise, it only keeps the suggestions from being taller than the terminal.
+// There's never any space above the input since output that was already printed can't be drawn over,
+// so suggestions are only moved above the input when using the [ViewportRenderer].
+func (u *UnmanagedRenderer) AvailableSpace() (int, int) {
+	if u.height <= 0 {
+		// The size isn't known yet
+		return 0, math.MaxInt
+	}
+	return 0, max(u.height-lipgloss.Height(u.input), 0)
+}
+
 func (u *UnmanagedRenderer) Input() string {
 	return u.input
 }
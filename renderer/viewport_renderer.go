@@ -5,6 +5,7 @@ import (
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
 type ViewportRenderer struct {
@@ -12,6 +13,8 @@ type ViewportRenderer struct {
 	history  string
 	input    string
 	body     string
+	popup    string
+	keyMap   KeyMap
 	settings rendererSettings
 }
 
@@ -24,7 +27,8 @@ func NewViewportRenderer(options ...Option) *ViewportRenderer {
 	for _, option := range options {
 		option(&settings)
 	}
-	return &ViewportRenderer{settings: settings}
+	// Creating the viewport with New keeps it from replacing the key map with its defaults on the first update
+	return &ViewportRenderer{viewport: viewport.New(0, 0), settings: settings, keyMap: DefaultKeyMap()}
 }
 
 func (v *ViewportRenderer) View() string {
@@ -33,8 +37,18 @@ func (v *ViewportRenderer) View() string {
 
 func (v *ViewportRenderer) Initialize(msg tea.WindowSizeMsg) {
 	v.SetSize(msg)
-	v.viewport.KeyMap.Up = key.NewBinding(key.WithKeys("ctrl+up"))
-	v.viewport.KeyMap.Down = key.NewBinding(key.WithKeys("ctrl+down"))
+	v.SetKeyMap(v.keyMap)
+}
+
+func (v *ViewportRenderer) SetKeyMap(keyMap KeyMap) {
+	v.keyMap = keyMap
+	// Leave the rest of the viewport's bindings unset since they would conflict with typing in the input
+	v.viewport.KeyMap = viewport.KeyMap{
+		Up:       keyMap.ScrollUp,
+		Down:     keyMap.ScrollDown,
+		PageUp:   keyMap.PageUp,
+		PageDown: keyMap.PageDown,
+	}
 }
 
 func (v *ViewportRenderer) SetSize(msg tea.WindowSizeMsg) {
@@ -59,8 +73,29 @@ func (v *ViewportRenderer) SetBody(body string) {
 	v.updateContent()
 }
 
+// SetPopup is part of the [PopupSetter] interface.
+// The popup is drawn on top of the end of the history.
+func (v *ViewportRenderer) SetPopup(popup string) {
+	v.popup = popup
+	v.updateContent()
+}
+
+// AvailableSpace is part of the [SpaceReporter] interface.
+// The space above the input is taken up by the history that's visible when the viewport is scrolled to the bottom
+// and the space below the input is whatever is left over.
+func (v *ViewportRenderer) AvailableSpace() (int, int) {
+	inputHeight := lipgloss.Height(v.input)
+	historyHeight := 0
+	if v.history != "" {
+		historyHeight = lipgloss.Height(internal.TrimNewline(v.history))
+	}
+	above := max(min(historyHeight, v.viewport.Height-inputHeight), 0)
+	below := max(v.viewport.Height-historyHeight-inputHeight, 0)
+	return above, below
+}
+
 func (v *ViewportRenderer) updateContent() {
-	v.viewport.SetContent(v.history + v.input + "\n" + v.body)
+	v.viewport.SetContent(placePopup(v.history, v.popup) + v.input + "\n" + v.body)
 }
 
 func (v *ViewportRenderer) GetHistory() string {
@@ -86,7 +121,7 @@ func (v *ViewportRenderer) FinishUpdate() tea.Cmd {
 
 func (v *ViewportRenderer) GotoBottom(msg tea.Msg) {
 	keyMsg, isKeyMsg := msg.(tea.KeyMsg)
-	if !isKeyMsg || (keyMsg.String() != "ctrl+up" && keyMsg.String() != "ctrl+down") {
+	if !isKeyMsg || !key.Matches(keyMsg, v.keyMap.bindings()...) {
 		v.viewport.GotoBottom()
 	}
 }
@@ -0,0 +1,58 @@
+package renderer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestViewportRendererKeyMap(t *testing.T) {
+	customKeyMap := DefaultKeyMap()
+	customKeyMap.ScrollUp = key.NewBinding(key.WithKeys("alt+k"))
+
+	tests := []struct {
+		name   string
+		keyMap *KeyMap
+		keys   []tea.KeyMsg
+		// top is the first line that's visible after the keys are pressed
+		top string
+	}{
+		{name: "bottom", top: "9"},
+		{name: "default scroll up", keys: []tea.KeyMsg{{Type: tea.KeyCtrlUp}}, top: "8"},
+		{name: "default page up", keys: []tea.KeyMsg{{Type: tea.KeyPgUp}}, top: "5"},
+		{
+			name:   "custom scroll up",
+			keyMap: &customKeyMap,
+			keys:   []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune("k"), Alt: true}},
+			top:    "8",
+		},
+		{name: "replaced binding", keyMap: &customKeyMap, keys: []tea.KeyMsg{{Type: tea.KeyCtrlUp}}, top: "9"},
+		{name: "other keys scroll to bottom", keys: []tea.KeyMsg{{Type: tea.KeyCtrlUp}, {Type: tea.KeyLeft}}, top: "9"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := NewViewportRenderer()
+			r.Initialize(tea.WindowSizeMsg{Width: 80, Height: 4})
+			if test.keyMap != nil {
+				r.SetKeyMap(*test.keyMap)
+			}
+			lines := []string{}
+			for i := 1; i <= 10; i++ {
+				lines = append(lines, fmt.Sprint(i))
+			}
+			r.SetHistory(strings.Join(lines, "\n"))
+			r.SetInput("> ")
+			r.GotoBottom(nil)
+			for _, key := range test.keys {
+				r.Update(key)
+				r.GotoBottom(key)
+			}
+			if top := strings.Split(r.View(), "\n")[0]; strings.TrimSpace(top) != test.top {
+				t.Errorf("top line = %q, want %q", top, test.top)
+			}
+		})
+	}
+}
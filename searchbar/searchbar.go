@@ -6,6 +6,7 @@ import (
 	prompt "github.com/aschey/bubbleprompt"
 	"github.com/aschey/bubbleprompt/input"
 	"github.com/aschey/bubbleprompt/renderer"
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/muesli/ansi"
@@ -37,18 +38,6 @@ func New[T any](inputHandler prompt.InputHandler[T], textInput input.Input[T],
 		option(&settings)
 	}
 
-	promptModel := prompt.New(inputHandler, textInput,
-		append(settings.promptOptions,
-			prompt.WithUnmanagedRenderer[T](renderer.WithUseHistory(false)))...)
-	borderWidth := 0
-	hasBorder := promptModel.SuggestionManager().Formatters().Suggestions.GetBorderLeft()
-	if hasBorder {
-		borderWidth = 2
-		textInput.SetPrompt("  ")
-	} else {
-		textInput.SetPrompt("")
-	}
-
 	searchBar := settings.searchbarStyle.PaddingRight(settings.maxWidth).Render(settings.label)
 	searchbarLines := strings.Split(searchBar, "\n")
 	searchbarHeight := len(searchbarLines)
@@ -60,6 +49,22 @@ func New[T any](inputHandler prompt.InputHandler[T], textInput input.Input[T],
 			placeholderStart = ansi.PrintableRuneWidth(line[:textIndex])
 		}
 	}
+
+	// The prompt is drawn on the line with the label, so the suggestions can only use the lines below it
+	promptModel := prompt.New(inputHandler, textInput,
+		append(settings.promptOptions,
+			prompt.WithUnmanagedRenderer[T](
+				renderer.WithUseHistory(false),
+				renderer.WithHeightOffset(placeholderLine),
+			))...)
+	borderWidth := 0
+	hasBorder := promptModel.SuggestionManager().Formatters().Suggestions.GetBorderLeft()
+	if hasBorder {
+		borderWidth = 2
+		textInput.SetPrompt("  ")
+	} else {
+		textInput.SetPrompt("")
+	}
 	return Model[T]{
 		promptModel:      promptModel,
 		contentModel:     contentModel,
@@ -92,8 +97,7 @@ func (m Model[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, cmd)
 		updateContent = false
 	case tea.KeyMsg:
-		switch msg.Type {
-		case tea.KeyEnter:
+		if key.Matches(msg, m.promptModel.KeyMap().Submit) {
 			cmds = append(cmds, prompt.Blur())
 		}
 	}
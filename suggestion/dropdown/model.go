@@ -1,47 +1,25 @@
 package dropdown
 
 import (
-	"math"
-
 	"github.com/aschey/bubbleprompt/input"
-	"github.com/aschey/bubbleprompt/suggestion"
+	"github.com/aschey/bubbleprompt/suggestion/internal/layout"
+	"github.com/aschey/bubbleprompt/suggestion/internal/list"
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/mattn/go-runewidth"
 )
 
 type Model[T any] struct {
-	textInput          input.Input[T]
-	suggestions        []suggestion.Suggestion[T]
-	lastKeyMsg         tea.KeyMsg
-	showSuggestions    bool
-	maxSuggestions     int
-	scrollPosition     int
-	prevScroll         int
-	selectedKey        *string
-	prevRunes          []rune
-	sequenceNumber     int
-	selectionIndicator string
-	scrollbar          string
-	scrollbarThumb     string
-	formatters         suggestion.Formatters
-	err                error
+	*list.Base[T]
 }
 
 func New[T any](textInput input.Input[T], options ...Option[T]) *Model[T] {
-	defaultMaxSuggestions := 6
-	m := &Model[T]{
-		textInput:          textInput,
-		maxSuggestions:     defaultMaxSuggestions,
-		showSuggestions:    false,
-		selectionIndicator: "",
-		scrollbar:          " ",
-		scrollbarThumb:     " ",
-		sequenceNumber:     -1,
-		formatters:         suggestion.DefaultFormatters(),
-		// Need to set the previous text to something in order to force the initial render
-		prevRunes: []rune(" "),
-	}
+	m := &Model[T]{}
+	m.Base = list.New(textInput, list.Arrangement{
+		Columns:  func() int { return 1 },
+		Navigate: m.navigate,
+	})
 	for _, option := range options {
 		option(m)
 	}
@@ -49,219 +27,23 @@ func New[T any](textInput input.Input[T], options ...Option[T]) *Model[T] {
 	return m
 }
 
-func (m *Model[T]) Init() tea.Cmd {
-	// Since the user hasn't typed anything on init, call the completer with empty text
-	return m.ResetSuggestions()
-}
-
-func (m *Model[T]) Update(msg tea.Msg) tea.Cmd {
-	switch msg := msg.(type) {
-	case suggestion.SuggestionMsg[T]:
-		if m.sequenceNumber < msg.SequenceNumber {
-			m.sequenceNumber = msg.SequenceNumber
-			if msg.Suggestions == nil {
-				m.suggestions = []suggestion.Suggestion[T]{}
-			} else {
-				m.suggestions = msg.Suggestions
-			}
-
-			m.err = msg.Err
-			// Selection is out of range of the current view or the key is no longer present
-			if m.scrollPosition > len(m.suggestions)-1 || m.SelectedSuggestion() == nil {
-				m.UnselectSuggestion()
-			}
-		}
-	case suggestion.PeriodicCompleterMsg:
-		if !m.canUpdateSuggestions() {
-			return suggestion.PeriodicCompleter(msg.NextTrigger)
-		}
-		return tea.Batch(m.forceUpdateSuggestions(), suggestion.PeriodicCompleter(msg.NextTrigger))
-	case suggestion.OneShotCompleterMsg:
-		if !m.canUpdateSuggestions() {
-			return nil
-		}
-		return m.forceUpdateSuggestions()
-	case tea.KeyMsg:
-		m.lastKeyMsg = msg
-		switch msg.Type {
-		case tea.KeyTab:
-			// Tab suggestion may have changed text so reset previous value
-			m.prevRunes = []rune("")
-			m.NextSuggestion()
-			m.updateIfUnselected()
-		case tea.KeyUp:
-			m.PreviousSuggestion()
-			m.updateIfUnselected()
-		case tea.KeyDown:
-			m.NextSuggestion()
-			m.updateIfUnselected()
-		}
-	}
-	return nil
-}
-
-func (m *Model[T]) updateIfUnselected() tea.Cmd {
-	if m.IsSuggestionSelected() {
-		// Set the input to the suggestion's selected text
-		return nil
-	} else {
-		// Need to update suggestions since we changed the text and the cursor position
-		return m.UpdateSuggestions()
-	}
-}
-
-func (c Model[T]) canUpdateSuggestions() bool {
-	runes := c.textInput.Runes()
-	if len(c.textInput.SuggestionRunes(runes[:c.textInput.CursorIndex()])) == 0 {
-		return true
-	}
-
-	switch c.lastKeyMsg.Type {
-	case tea.KeyUp, tea.KeyDown, tea.KeyTab:
-		return false
+// navigate moves the selection up or down the list.
+func (m *Model[T]) navigate(msg tea.KeyMsg) bool {
+	keyMap := m.KeyMap()
+	switch {
+	case key.Matches(msg, keyMap.Previous):
+		m.PreviousSuggestion()
+	case key.Matches(msg, keyMap.Next):
+		m.NextSuggestion()
 	default:
-		return true
-	}
-}
-
-func (c Model[T]) ScrollbarBounds() (int, int) {
-	windowHeight := c.windowHeight()
-	contentHeight := len(c.suggestions)
-	// The zero-based index of the first element that will be shown when the content is scrolled to the bottom
-	lastSegmentStart := contentHeight - windowHeight
-	scrollbarHeight := int(math.Max(float64(windowHeight-lastSegmentStart), 1))
-	scrollbarPos := float64(
-		c.scrollPosition,
-	) * (float64(windowHeight-scrollbarHeight) / float64(lastSegmentStart))
-
-	// If scrolling up, use ceiling operation to ensure the scrollbar is only at the top when the first row is shown
-	// otherwise use floor operation
-	var scrollbarTop int
-	if c.prevScroll > c.scrollPosition {
-		scrollbarTop = int(math.Ceil(scrollbarPos))
-	} else {
-		scrollbarTop = int(math.Floor(scrollbarPos))
-	}
-
-	return scrollbarTop, scrollbarTop + scrollbarHeight
-}
-
-func (m *Model[T]) SetShowSuggestions(showSuggestions bool) {
-	m.showSuggestions = showSuggestions
-}
-
-func (m *Model[T]) UpdateSuggestions() tea.Cmd {
-	return m.updateSuggestionsCmd(false)
-}
-
-func (m *Model[T]) forceUpdateSuggestions() tea.Cmd {
-	return m.updateSuggestionsCmd(true)
-}
-
-func (m *Model[T]) updateSuggestionsCmd(forceUpdate bool) tea.Cmd {
-	runes := m.textInput.Runes()
-	cursorPos := m.textInput.CursorIndex()
-
-	runesBeforeCursor := runes
-	if cursorPos < len(runes) {
-		runesBeforeCursor = runes[:cursorPos]
-	}
-
-	// No need to queue another update if the text hasn't changed
-	// Don't trim whitespace here because cursor location affects suggestions
-	if !forceUpdate && string(runesBeforeCursor) == string(m.prevRunes) {
-		return nil
-	}
-
-	m.prevRunes = runesBeforeCursor
-
-	return suggestion.Complete
-}
-
-func (m *Model[T]) ResetSuggestions() tea.Cmd {
-	m.prevRunes = []rune("")
-	return suggestion.Complete
-}
-
-func (m *Model[T]) UnselectSuggestion() {
-	m.selectedKey = nil
-	m.scrollPosition = 0
-	m.prevScroll = 0
-	m.textInput.OnSuggestionUnselected()
-}
-
-func (m *Model[T]) ClearSuggestions() {
-	m.UnselectSuggestion()
-	m.suggestions = []suggestion.Suggestion[T]{}
-}
-
-func (m *Model[T]) SelectSuggestion(suggestion suggestion.Suggestion[T]) {
-	m.selectedKey = suggestion.Key()
-	m.textInput.OnSuggestionChanged(suggestion)
-}
-
-func (m *Model[T]) IsSuggestionSelected() bool {
-	return m.selectedKey != nil
-}
-
-func (m *Model[T]) NextSuggestion() {
-	if len(m.suggestions) == 0 {
-		return
-	}
-	index := m.SelectedIndex()
-	if index < len(m.suggestions)-1 {
-		m.prevScroll = m.scrollPosition
-		m.SelectSuggestion(m.suggestions[index+1])
-		if index+1 >= m.scrollPosition+m.maxSuggestions {
-			m.scrollPosition++
-		}
-
-	} else {
-		m.UnselectSuggestion()
-	}
-}
-
-func (m *Model[T]) PreviousSuggestion() {
-	if len(m.suggestions) == 0 {
-		return
-	}
-
-	index := m.SelectedIndex()
-	if index > 0 {
-		m.prevScroll = m.scrollPosition
-		m.SelectSuggestion(m.suggestions[index-1])
-		if index-1 < m.scrollPosition {
-			m.scrollPosition--
-		}
-	} else {
-		m.UnselectSuggestion()
-	}
-}
-
-func (m *Model[T]) SelectedIndex() int {
-	if m.IsSuggestionSelected() {
-		for i, suggestion := range m.suggestions {
-			if *suggestion.Key() == *m.selectedKey {
-				return i
-			}
-		}
-	}
-	return -1
-}
-
-func (m *Model[T]) SelectedSuggestion() *suggestion.Suggestion[T] {
-	if m.IsSuggestionSelected() {
-		for _, suggestion := range m.suggestions {
-			if *suggestion.Key() == *m.selectedKey {
-				return &suggestion
-			}
-		}
+		return false
 	}
-	return nil
+	return true
 }
 
 func (c Model[T]) MaxSuggestionWidth() (int, int) {
 	suggestions := c.Suggestions()
+	formatters := c.Formatters()
 
 	maxNameLen := 0
 	maxDescLen := 0
@@ -269,12 +51,12 @@ func (c Model[T]) MaxSuggestionWidth() (int, int) {
 	// Determine longest name and description to calculate padding
 	for _, cur := range suggestions {
 		suggestionText := cur.GetSuggestionText()
-		textWidth := runewidth.StringWidth(suggestionText)
+		textWidth := formatters.Name.Width(suggestionText)
 		if textWidth > maxNameLen {
 			maxNameLen = textWidth
 		}
 
-		descWidth := runewidth.StringWidth(cur.Description)
+		descWidth := formatters.Description.Width(cur.Description)
 		if descWidth > maxDescLen {
 			maxDescLen = descWidth
 		}
@@ -284,8 +66,9 @@ func (c Model[T]) MaxSuggestionWidth() (int, int) {
 }
 
 func (c Model[T]) Render(paddingSize int) string {
+	formatters := c.Formatters()
 	if c.Error() != nil {
-		return c.formatters.ErrorText.Render(c.Error().Error())
+		return formatters.ErrorFormatter().Render(c.Error())
 	}
 
 	suggestions := c.Suggestions()
@@ -295,130 +78,64 @@ func (c Model[T]) Render(paddingSize int) string {
 
 	maxNameLen, maxDescLen := c.MaxSuggestionWidth()
 
-	numSuggestions := len(c.Suggestions())
-
-	visibleSuggestions := c.VisibleSuggestions()
-	scrollbarStart, scrollbarEnd := c.ScrollbarBounds()
+	rows := c.Rows()
+	window := c.Window()
 
 	prompts := []string{}
-	listPosition := c.SelectedIndex() - c.ScrollPosition()
-	scrollbar := c.formatters.Scrollbar.Render(c.Scrollbar())
-	scrollbarThumb := c.formatters.ScrollbarThumb.Render(c.ScrollbarThumb())
-	for i, cur := range visibleSuggestions {
-		selected := i == listPosition
-		scrollbarView := ""
-		if numSuggestions > c.MaxSuggestions() {
-			if scrollbarStart <= i && i < scrollbarEnd {
-				scrollbarView = scrollbarThumb
-			} else {
-				scrollbarView = scrollbar
-			}
+	selectedIndex := c.SelectedIndex()
+	scrollbar := formatters.Scrollbar.Render(c.Scrollbar())
+	scrollbarThumb := formatters.ScrollbarThumb.Render(c.ScrollbarThumb())
+	for i, row := range window.Visible(rows) {
+		scrollbarView := window.Scrollbar(len(rows), i, scrollbar, scrollbarThumb)
+		if row.IsHeader() {
+			prompts = append(prompts, c.renderGroupHeader(row.Header, scrollbarView))
+			continue
 		}
-
+		index := row.Indexes[0]
+		cur := suggestions[index]
+		selected := index == selectedIndex
 		line := cur.Render(
 			selected,
 			maxNameLen,
 			maxDescLen,
-			c.formatters,
+			formatters,
 			scrollbarView,
 			c.SelectionIndicator(),
 		)
 		prompts = append(prompts, line)
 	}
-	hasBorder := c.formatters.Suggestions.GetBorderLeft()
+	hasBorder := formatters.Suggestions.GetBorderLeft()
 
 	allPrompts := lipgloss.JoinVertical(lipgloss.Left, prompts...)
 
 	if hasBorder {
 		borderPadding := 2
-		return c.formatters.Suggestions.
+		return formatters.Suggestions.
 			MarginLeft(paddingSize - borderPadding).
 			PaddingLeft(1).
 			Render(allPrompts)
 	} else {
-		return c.formatters.Suggestions.
+		return formatters.Suggestions.
 			PaddingLeft(paddingSize).
 			Render(allPrompts)
 	}
 }
 
-func (m *Model[T]) EnableScrollbar() {
-	m.scrollbar = " "
-	m.scrollbarThumb = " "
-}
-
-func (m *Model[T]) DisableScrollbar() {
-	m.scrollbar = ""
-	m.scrollbarThumb = ""
-}
-
-func (m *Model[T]) MaxSuggestions() int {
-	return m.maxSuggestions
-}
-
-func (m *Model[T]) SetMaxSuggestions(maxSuggestions int) {
-	m.maxSuggestions = maxSuggestions
-}
-
-func (m *Model[T]) SelectionIndicator() string {
-	return m.selectionIndicator
-}
-
-func (m *Model[T]) SetSelectionIndicator(selectionIndicator string) {
-	m.selectionIndicator = selectionIndicator
-}
-
-func (m *Model[T]) Formatters() suggestion.Formatters {
-	return m.formatters
-}
-
-func (m *Model[T]) SetFormatters(formatters suggestion.Formatters) {
-	m.formatters = formatters
-}
-
-func (m *Model[T]) Suggestions() []suggestion.Suggestion[T] {
-	if m.showSuggestions {
-		return m.suggestions
-	}
-	return []suggestion.Suggestion[T]{}
-}
-
-func (m *Model[T]) windowHeight() int {
-	windowHeight := len(m.Suggestions())
-	if windowHeight > m.MaxSuggestions() {
-		windowHeight = m.MaxSuggestions()
+func (c Model[T]) renderGroupHeader(header string, scrollbar string) string {
+	// Match the width of the suggestions so the scrollbar lines up
+	formatters := c.Formatters()
+	suggestions := c.Suggestions()
+	width := runewidth.StringWidth(c.SelectionIndicator())
+	if len(suggestions) > 0 {
+		maxNameLen, maxDescLen := c.MaxSuggestionWidth()
+		width += lipgloss.Width(suggestions[0].Render(false, maxNameLen, maxDescLen, formatters, "", ""))
 	}
-	return windowHeight
-}
-
-func (m *Model[T]) VisibleSuggestions() []suggestion.Suggestion[T] {
-	windowHeight := m.windowHeight()
-	visibleSuggestions := m.Suggestions()[m.scrollPosition : m.scrollPosition+windowHeight]
-	return visibleSuggestions
-}
-
-func (m *Model[T]) Error() error {
-	return m.err
-}
-
-func (m *Model[T]) ScrollPosition() int {
-	return m.scrollPosition
-}
-
-func (m *Model[T]) Scrollbar() string {
-	return m.scrollbar
-}
-
-func (m *Model[T]) ScrollbarThumb() string {
-	return m.scrollbarThumb
+	return layout.RenderGroupHeader(formatters, header, c.SelectionIndicator(), width) + scrollbar
 }
 
 func (m *Model[T]) ShouldChangeListPosition(msg tea.Msg) bool {
-	if key, ok := msg.(tea.KeyMsg); ok {
-		switch key.Type {
-		case tea.KeyUp, tea.KeyDown, tea.KeyTab:
-			return true
-		}
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		return m.KeyMap().Matches(keyMsg)
 	}
 
 	return false
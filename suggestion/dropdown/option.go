@@ -21,3 +21,9 @@ func WithFormatters[T any](formatters suggestion.Formatters) Option[T] {
 		model.SetFormatters(formatters)
 	}
 }
+
+func WithKeyMap[T any](keyMap suggestion.KeyMap) Option[T] {
+	return func(model *Model[T]) {
+		model.SetKeyMap(keyMap)
+	}
+}
@@ -0,0 +1,122 @@
+package suggestion
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Hinter can optionally be implemented by an error to show a hint below the error message,
+// such as how to fix the problem.
+type Hinter interface {
+	Hint() string
+}
+
+// ErrorFormatter handles styling for errors from completers and executors.
+// Errors combined with [errors.Join] are shown separately and wrapped errors are listed as causes.
+type ErrorFormatter struct {
+	// Message handles styling for the error message.
+	Message lipgloss.Style
+	// Cause handles styling for the errors that were wrapped by the error.
+	Cause lipgloss.Style
+	// Hint handles styling for hints from errors that implement [Hinter].
+	Hint lipgloss.Style
+}
+
+// Render formats the error.
+func (f ErrorFormatter) Render(err error) string {
+	if err == nil {
+		return ""
+	}
+	hints := []string{}
+	err = collapseError(err, &hints)
+	if joined := joinedErrors(err); joined != nil {
+		lines := []string{}
+		for _, inner := range joined {
+			lines = append(lines, f.Render(inner))
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	lines := []string{f.Message.Render(errorMessage(err))}
+	f.renderCauses(err, 1, &lines, &hints)
+	for _, hint := range hints {
+		lines = append(lines, f.Hint.Render("hint: "+hint))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (f ErrorFormatter) renderCauses(err error, depth int, lines *[]string, hints *[]string) {
+	for _, cause := range errorCauses(err) {
+		f.renderCause(cause, depth, lines, hints)
+	}
+}
+
+func (f ErrorFormatter) renderCause(cause error, depth int, lines *[]string, hints *[]string) {
+	cause = collapseError(cause, hints)
+	if joined := joinedErrors(cause); joined != nil {
+		for _, inner := range joined {
+			f.renderCause(inner, depth, lines, hints)
+		}
+		return
+	}
+	indent := strings.Repeat("  ", depth)
+	*lines = append(*lines, indent+f.Cause.Render("caused by: "+errorMessage(cause)))
+	f.renderCauses(cause, depth+1, lines, hints)
+}
+
+// collapseError skips over errors that only wrap another error without adding to the message,
+// such as errors that add a hint.
+func collapseError(err error, hints *[]string) error {
+	for {
+		if hinter, ok := err.(Hinter); ok && hinter.Hint() != "" && !slices.Contains(*hints, hinter.Hint()) {
+			*hints = append(*hints, hinter.Hint())
+		}
+		cause := errors.Unwrap(err)
+		if cause == nil || cause.Error() != err.Error() {
+			return err
+		}
+		err = cause
+	}
+}
+
+// joinedErrors returns the errors that were combined with [errors.Join] or nil if the error wasn't created that way.
+func joinedErrors(err error) []error {
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok || len(joined.Unwrap()) == 0 {
+		return nil
+	}
+	messages := []string{}
+	for _, inner := range joined.Unwrap() {
+		messages = append(messages, inner.Error())
+	}
+	// Errors that wrap multiple errors with fmt.Errorf have their own message
+	if strings.Join(messages, "\n") != err.Error() {
+		return nil
+	}
+	return joined.Unwrap()
+}
+
+func errorCauses(err error) []error {
+	switch err := err.(type) {
+	case interface{ Unwrap() []error }:
+		return err.Unwrap()
+	case interface{ Unwrap() error }:
+		if cause := err.Unwrap(); cause != nil {
+			return []error{cause}
+		}
+	}
+	return nil
+}
+
+// errorMessage returns the error's message without the message of the error it wraps
+// since that's shown separately.
+func errorMessage(err error) string {
+	message := err.Error()
+	if cause := errors.Unwrap(err); cause != nil {
+		message = strings.TrimRight(strings.TrimSuffix(message, cause.Error()), ": ")
+	}
+	return message
+}
@@ -0,0 +1,50 @@
+package suggestion_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aschey/bubbleprompt/suggestion"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type hintError struct {
+	error
+	hint string
+}
+
+func (e hintError) Unwrap() error {
+	return e.error
+}
+
+func (e hintError) Hint() string {
+	return e.hint
+}
+
+func ExampleErrorFormatter_Render() {
+	notFound := hintError{errors.New("file not found"), "run init to create the config file"}
+	err := errors.Join(
+		fmt.Errorf("load config: %w", notFound),
+		errors.New("no network connection"),
+	)
+
+	fmt.Println(suggestion.ErrorFormatter{}.Render(err))
+	// Output:
+	// load config
+	//   caused by: file not found
+	// hint: run init to create the config file
+	// no network connection
+}
+
+func ExampleFormatters_ErrorFormatter() {
+	formatters := suggestion.DefaultFormatters()
+	// Setting the deprecated ErrorText style still applies when the message style isn't set
+	formatters.ErrorText = lipgloss.NewStyle().SetString("error:")
+	fmt.Println(formatters.ErrorFormatter().Render(errors.New("no network connection")))
+
+	formatters.Error.Message = lipgloss.NewStyle().SetString("failed:")
+	fmt.Println(formatters.ErrorFormatter().Render(errors.New("no network connection")))
+	// Output:
+	// error: no network connection
+	// failed: no network connection
+}
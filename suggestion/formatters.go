@@ -1,12 +1,26 @@
 package suggestion
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"reflect"
+
+	"github.com/charmbracelet/lipgloss"
+)
 
 type Formatters struct {
-	Name              SuggestionText
-	Description       SuggestionText
-	ErrorText         lipgloss.Style
+	Name        SuggestionText
+	Description SuggestionText
+	Match       lipgloss.Style
+	// ErrorText is used for error messages when Error.Message isn't set.
+	//
+	// Deprecated: set the Message style of Error instead.
+	ErrorText lipgloss.Style
+	// Error handles styling for errors. Use [Formatters.ErrorFormatter] to render errors
+	// so the deprecated ErrorText style is still applied.
+	Error             ErrorFormatter
+	Output            OutputFormatter
+	Preview           PreviewFormatter
 	SelectedIndicator lipgloss.Style
+	GroupHeader       lipgloss.Style
 	Scrollbar         lipgloss.Style
 	ScrollbarThumb    lipgloss.Style
 	Suggestions       lipgloss.Style
@@ -25,6 +39,8 @@ var (
 	DefaultSelectedDescriptionForeground = "0"
 	DefaultSelectedDescriptionBackground = "6"
 	DefaultErrorTextBackground           = "1"
+	DefaultErrorCauseForeground          = "9"
+	DefaultErrorHintForeground           = "3"
 )
 
 var (
@@ -34,6 +50,26 @@ var (
 
 var DefaultIndicatorForeground = "8"
 
+var DefaultMatchForeground = "13"
+
+var DefaultGroupHeaderForeground = "245"
+
+var (
+	DefaultPreviewBorderForeground = "240"
+	DefaultPreviewStatusForeground = "245"
+)
+
+var (
+	DefaultTableHeaderForeground     = "12"
+	DefaultTableBorderForeground     = "240"
+	DefaultTreeBranchForeground      = "240"
+	DefaultDataKeyForeground         = "12"
+	DefaultDataStringForeground      = "10"
+	DefaultDataNumberForeground      = "11"
+	DefaultDataLiteralForeground     = "13"
+	DefaultDataPunctuationForeground = "245"
+)
+
 func DefaultFormatters() Formatters {
 	return Formatters{
 		Name: SuggestionText{
@@ -57,15 +93,75 @@ func DefaultFormatters() Formatters {
 				Foreground(lipgloss.Color(DefaultDescriptionForeground)).
 				Background(lipgloss.Color(DefaultDescriptionBackground)),
 		},
+		Match: lipgloss.
+			NewStyle().
+			Underline(true).
+			Foreground(lipgloss.Color(DefaultMatchForeground)),
 		SelectedIndicator: lipgloss.
 			NewStyle().
 			Foreground(lipgloss.Color(DefaultIndicatorForeground)),
 
+		GroupHeader: lipgloss.
+			NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color(DefaultGroupHeaderForeground)),
+
 		ErrorText: lipgloss.
 			NewStyle().
 			PaddingLeft(1).
 			PaddingRight(1).
 			Background(lipgloss.Color(DefaultErrorTextBackground)),
+		// The message style is left unset so it falls back to ErrorText
+		Error: ErrorFormatter{
+			Cause: lipgloss.
+				NewStyle().
+				Foreground(lipgloss.Color(DefaultErrorCauseForeground)),
+			Hint: lipgloss.
+				NewStyle().
+				Italic(true).
+				Foreground(lipgloss.Color(DefaultErrorHintForeground)),
+		},
+		Preview: PreviewFormatter{
+			Pane: lipgloss.
+				NewStyle().
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(lipgloss.Color(DefaultPreviewBorderForeground)).
+				Padding(0, 1),
+			Status: lipgloss.
+				NewStyle().
+				Foreground(lipgloss.Color(DefaultPreviewStatusForeground)),
+		},
+		Output: OutputFormatter{
+			TableHeader: lipgloss.
+				NewStyle().
+				Bold(true).
+				Padding(0, 1).
+				Foreground(lipgloss.Color(DefaultTableHeaderForeground)),
+			TableCell: lipgloss.
+				NewStyle().
+				Padding(0, 1),
+			TableBorder: lipgloss.
+				NewStyle().
+				Foreground(lipgloss.Color(DefaultTableBorderForeground)),
+			TreeBranch: lipgloss.
+				NewStyle().
+				Foreground(lipgloss.Color(DefaultTreeBranchForeground)),
+			DataKey: lipgloss.
+				NewStyle().
+				Foreground(lipgloss.Color(DefaultDataKeyForeground)),
+			DataString: lipgloss.
+				NewStyle().
+				Foreground(lipgloss.Color(DefaultDataStringForeground)),
+			DataNumber: lipgloss.
+				NewStyle().
+				Foreground(lipgloss.Color(DefaultDataNumberForeground)),
+			DataLiteral: lipgloss.
+				NewStyle().
+				Foreground(lipgloss.Color(DefaultDataLiteralForeground)),
+			DataPunctuation: lipgloss.
+				NewStyle().
+				Foreground(lipgloss.Color(DefaultDataPunctuationForeground)),
+		},
 		Scrollbar: lipgloss.
 			NewStyle().
 			Background(lipgloss.Color(DefaultScrollbarColor)),
@@ -75,6 +171,16 @@ func DefaultFormatters() Formatters {
 	}
 }
 
+// ErrorFormatter returns the formatter for errors.
+// The deprecated ErrorText style is used for the message if Error.Message isn't set.
+func (f Formatters) ErrorFormatter() ErrorFormatter {
+	formatter := f.Error
+	if reflect.DeepEqual(formatter.Message, lipgloss.Style{}) {
+		formatter.Message = f.ErrorText
+	}
+	return formatter
+}
+
 func (f Formatters) Minimal() Formatters {
 	f.Name.Style = f.Name.Style.
 		UnsetBackground().
@@ -0,0 +1,31 @@
+package grid
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// KeyMap defines the keys used to move around the grid in addition to the suggestion key bindings.
+// The keys are only handled while a suggestion is selected so they can still be used to edit the input otherwise.
+type KeyMap struct {
+	Left     key.Binding
+	Right    key.Binding
+	PageUp   key.Binding
+	PageDown key.Binding
+}
+
+// DefaultKeyMap returns the default grid key bindings.
+// Page up and page down aren't bound by default since they're used by the prompt's renderer.
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Left:     key.NewBinding(key.WithKeys("left"), key.WithHelp("←", "previous column")),
+		Right:    key.NewBinding(key.WithKeys("right"), key.WithHelp("→", "next column")),
+		PageUp:   key.NewBinding(key.WithKeys("shift+up"), key.WithHelp("shift+↑", "previous page")),
+		PageDown: key.NewBinding(key.WithKeys("shift+down"), key.WithHelp("shift+↓", "next page")),
+	}
+}
+
+// Matches reports whether the message matches any of the grid key bindings.
+func (k KeyMap) Matches(msg tea.KeyMsg) bool {
+	return key.Matches(msg, k.Left, k.Right, k.PageUp, k.PageDown)
+}
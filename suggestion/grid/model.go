@@ -0,0 +1,199 @@
+// Package grid provides a suggestion manager that lays suggestions out in columns
+// that fill the width of the terminal, similar to zsh's menu selection.
+package grid
+
+import (
+	"strings"
+
+	"github.com/aschey/bubbleprompt/input"
+	"github.com/aschey/bubbleprompt/suggestion/internal/layout"
+	"github.com/aschey/bubbleprompt/suggestion/internal/list"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// Space between each column
+const columnGap = 1
+
+// Model is a [suggestion.Manager] that shows suggestions in a grid.
+// Suggestions are laid out from left to right in as many columns as will fit in the terminal
+// and only their names are shown.
+// The up and down keys move between rows and the keys in the [KeyMap] move between columns and pages
+// once a suggestion is selected.
+// MaxSuggestions is the maximum number of rows that are shown before the grid scrolls.
+type Model[T any] struct {
+	*list.Base[T]
+	width      int
+	gridKeyMap KeyMap
+}
+
+func New[T any](textInput input.Input[T], options ...Option[T]) *Model[T] {
+	m := &Model[T]{gridKeyMap: DefaultKeyMap()}
+	m.Base = list.New(textInput, list.Arrangement{
+		Columns:         func() int { return m.columns() },
+		Navigate:        m.navigate,
+		IsNavigationKey: func(msg tea.KeyMsg) bool { return m.gridKeyMap.Matches(msg) },
+	})
+	for _, option := range options {
+		option(m)
+	}
+
+	return m
+}
+
+func (m *Model[T]) Update(msg tea.Msg) tea.Cmd {
+	if msg, ok := msg.(tea.WindowSizeMsg); ok {
+		// The number of columns depends on the width
+		m.width = msg.Width
+	}
+	return m.Base.Update(msg)
+}
+
+// navigate moves the selection between rows, columns and pages.
+func (m *Model[T]) navigate(msg tea.KeyMsg) bool {
+	keyMap := m.KeyMap()
+	switch {
+	case key.Matches(msg, keyMap.Previous):
+		if m.IsSuggestionSelected() && !m.moveRows(-1) {
+			m.UnselectSuggestion()
+		}
+	case key.Matches(msg, keyMap.Next):
+		if !m.IsSuggestionSelected() {
+			m.NextSuggestion()
+		} else if !m.moveRows(1) {
+			m.UnselectSuggestion()
+		}
+	case !m.IsSuggestionSelected():
+		// The remaining keys are only used to navigate the grid once a suggestion is selected
+		return false
+	case key.Matches(msg, m.gridKeyMap.Left):
+		if index := m.SelectedIndex(); index > 0 {
+			m.SelectIndex(index - 1)
+		}
+	case key.Matches(msg, m.gridKeyMap.Right):
+		if index := m.SelectedIndex(); index < len(m.Suggestions())-1 {
+			m.SelectIndex(index + 1)
+		}
+	case key.Matches(msg, m.gridKeyMap.PageUp):
+		m.moveRows(-m.Window().MaxVisibleRows())
+	case key.Matches(msg, m.gridKeyMap.PageDown):
+		m.moveRows(m.Window().MaxVisibleRows())
+	default:
+		return false
+	}
+	return true
+}
+
+// moveRows moves the selection up or down by the given number of rows, staying in the same column if possible.
+// Group headers are skipped. It returns false if there are no more rows in that direction.
+func (m *Model[T]) moveRows(delta int) bool {
+	index := layout.Move(m.Rows(), m.SelectedIndex(), delta)
+	if index < 0 {
+		return false
+	}
+	m.SelectIndex(index)
+	return true
+}
+
+// MaxSuggestionWidth returns the width of the longest suggestion name.
+// Descriptions aren't shown in the grid, so the description width is always 0.
+func (c Model[T]) MaxSuggestionWidth() (int, int) {
+	maxNameLen := 0
+	for _, cur := range c.Suggestions() {
+		maxNameLen = max(maxNameLen, c.Formatters().Name.Width(cur.GetSuggestionText()))
+	}
+	return maxNameLen, 0
+}
+
+// cellWidth returns the width of each suggestion in the grid.
+func (c Model[T]) cellWidth() int {
+	suggestions := c.Suggestions()
+	if len(suggestions) == 0 {
+		return 0
+	}
+	maxNameLen, _ := c.MaxSuggestionWidth()
+	return lipgloss.Width(suggestions[0].Render(false, maxNameLen, 0, c.Formatters(), "", c.SelectionIndicator()))
+}
+
+// columns returns the number of columns that fit in the terminal.
+func (c Model[T]) columns() int {
+	if c.width <= 0 {
+		return 1
+	}
+	available := c.width -
+		runewidth.StringWidth(c.Scrollbar()) -
+		c.Formatters().Suggestions.GetHorizontalFrameSize()
+	return max((available+columnGap)/(c.cellWidth()+columnGap), 1)
+}
+
+// Render renders the grid starting at the left edge of the terminal so it can use the full width.
+// The padding size is ignored since the grid isn't aligned with the cursor.
+func (c Model[T]) Render(paddingSize int) string {
+	formatters := c.Formatters()
+	if c.Error() != nil {
+		return formatters.ErrorFormatter().Render(c.Error())
+	}
+
+	suggestions := c.Suggestions()
+	if len(suggestions) == 0 {
+		return ""
+	}
+
+	maxNameLen, _ := c.MaxSuggestionWidth()
+	rows := c.Rows()
+	window := c.Window()
+	columns := 0
+	for _, row := range rows {
+		columns = max(columns, len(row.Indexes))
+	}
+	gridWidth := columns*c.cellWidth() + (columns-1)*columnGap
+
+	selectedIndex := c.SelectedIndex()
+	scrollbar := formatters.Scrollbar.Render(c.Scrollbar())
+	scrollbarThumb := formatters.ScrollbarThumb.Render(c.ScrollbarThumb())
+	gap := strings.Repeat(" ", columnGap)
+
+	lines := []string{}
+	for i, row := range window.Visible(rows) {
+		scrollbarView := window.Scrollbar(len(rows), i, scrollbar, scrollbarThumb)
+		if row.IsHeader() {
+			lines = append(lines, layout.RenderGroupHeader(formatters, row.Header, c.SelectionIndicator(), gridWidth)+
+				scrollbarView)
+			continue
+		}
+		cells := []string{}
+		for _, index := range row.Indexes {
+			cells = append(cells, suggestions[index].Render(
+				index == selectedIndex,
+				maxNameLen,
+				0,
+				formatters,
+				"",
+				c.SelectionIndicator(),
+			))
+		}
+		line := strings.Join(cells, gap)
+		// Pad the last row so the scrollbar lines up
+		line += strings.Repeat(" ", max(gridWidth-lipgloss.Width(line), 0))
+		lines = append(lines, line+scrollbarView)
+	}
+
+	return formatters.Suggestions.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// SetGridKeyMap sets the keys used to move between columns and pages.
+func (m *Model[T]) SetGridKeyMap(keyMap KeyMap) {
+	m.gridKeyMap = keyMap
+}
+
+// ShouldChangeListPosition returns true for the suggestion keys and, once a suggestion is selected,
+// the keys used to move between columns and pages.
+func (m *Model[T]) ShouldChangeListPosition(msg tea.Msg) bool {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		return m.KeyMap().Matches(keyMsg) || (m.IsSuggestionSelected() && m.gridKeyMap.Matches(keyMsg))
+	}
+
+	return false
+}
@@ -0,0 +1,314 @@
+package grid
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/aschey/bubbleprompt/input/simpleinput"
+	"github.com/aschey/bubbleprompt/suggestion"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+const testMaxRows = 3
+
+var (
+	keyUp       = tea.KeyMsg{Type: tea.KeyUp}
+	keyDown     = tea.KeyMsg{Type: tea.KeyDown}
+	keyLeft     = tea.KeyMsg{Type: tea.KeyLeft}
+	keyRight    = tea.KeyMsg{Type: tea.KeyRight}
+	keyTab      = tea.KeyMsg{Type: tea.KeyTab}
+	keyPageUp   = tea.KeyMsg{Type: tea.KeyShiftUp}
+	keyPageDown = tea.KeyMsg{Type: tea.KeyShiftDown}
+)
+
+// testSuggestions creates suggestions named s0, s1, ... in the given groups.
+// Each entry in groups is the number of suggestions in that group.
+func testSuggestions(groups ...int) []suggestion.Suggestion[any] {
+	suggestions := []suggestion.Suggestion[any]{}
+	for i, count := range groups {
+		group := ""
+		if len(groups) > 1 {
+			group = fmt.Sprintf("group%d", i)
+		}
+		for range count {
+			suggestions = append(
+				suggestions,
+				suggestion.Suggestion[any]{Text: fmt.Sprintf("s%d", len(suggestions)), Group: group},
+			)
+		}
+	}
+	return suggestions
+}
+
+// newTestGrid creates a grid that's wide enough for the given number of columns.
+func newTestGrid(t *testing.T, columns int, suggestions []suggestion.Suggestion[any]) *Model[any] {
+	t.Helper()
+	m := New(simpleinput.New[any](), WithMaxSuggestions[any](testMaxRows))
+	m.SetShowSuggestions(true)
+	m.Update(suggestion.SuggestionMsg[any]{Suggestions: suggestions, SequenceNumber: 0})
+	m.Update(tea.WindowSizeMsg{Width: gridWidth(m, columns), Height: 24})
+	if got := m.columns(); got != columns {
+		t.Fatalf("columns = %d, want %d", got, columns)
+	}
+	return m
+}
+
+// gridWidth returns the smallest terminal width that fits the given number of columns.
+func gridWidth(m *Model[any], columns int) int {
+	return columns*m.cellWidth() + (columns-1)*columnGap + len(m.Scrollbar())
+}
+
+func selected(m *Model[any]) string {
+	if suggestion := m.SelectedSuggestion(); suggestion != nil {
+		return suggestion.Text
+	}
+	return ""
+}
+
+func TestGridColumns(t *testing.T) {
+	m := newTestGrid(t, 3, testSuggestions(7))
+	tests := []struct {
+		name    string
+		width   int
+		columns int
+	}{
+		{name: "exact fit", width: gridWidth(m, 3), columns: 3},
+		{name: "one less than fit", width: gridWidth(m, 3) - 1, columns: 2},
+		{name: "wide", width: gridWidth(m, 5) + 2, columns: 5},
+		{name: "narrower than a cell", width: 1, columns: 1},
+		{name: "unknown width", width: 0, columns: 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m.Update(tea.WindowSizeMsg{Width: test.width, Height: 24})
+			rows := m.Rows()
+			if got := len(rows[0].Indexes); got != test.columns {
+				t.Errorf("columns = %d, want %d", got, test.columns)
+			}
+			wantRows := (7 + test.columns - 1) / test.columns
+			if len(rows) != wantRows {
+				t.Errorf("rows = %d, want %d", len(rows), wantRows)
+			}
+		})
+	}
+}
+
+func TestGridNavigation(t *testing.T) {
+	tests := []struct {
+		name   string
+		groups []int
+		keys   []tea.KeyMsg
+		want   string
+	}{
+		{name: "down selects first", groups: []int{7}, keys: []tea.KeyMsg{keyDown}, want: "s0"},
+		{name: "tab selects first", groups: []int{7}, keys: []tea.KeyMsg{keyTab}, want: "s0"},
+		{name: "down moves a row", groups: []int{7}, keys: []tea.KeyMsg{keyDown, keyRight, keyDown}, want: "s4"},
+		{
+			name:   "down keeps column in short row",
+			groups: []int{7},
+			keys:   []tea.KeyMsg{keyDown, keyRight, keyRight, keyDown, keyDown},
+			want:   "s6",
+		},
+		{name: "down past last row unselects", groups: []int{4}, keys: []tea.KeyMsg{keyDown, keyDown, keyDown}},
+		{name: "up from first row unselects", groups: []int{7}, keys: []tea.KeyMsg{keyDown, keyUp}},
+		{name: "up moves a row", groups: []int{7}, keys: []tea.KeyMsg{keyDown, keyDown, keyRight, keyUp}, want: "s1"},
+		{
+			name:   "right wraps to next row",
+			groups: []int{7},
+			keys:   []tea.KeyMsg{keyTab, keyTab, keyTab, keyRight},
+			want:   "s3",
+		},
+		{name: "left wraps to previous row", groups: []int{7}, keys: []tea.KeyMsg{keyDown, keyDown, keyLeft}, want: "s2"},
+		{name: "left stays on first", groups: []int{7}, keys: []tea.KeyMsg{keyDown, keyLeft}, want: "s0"},
+		{name: "right stays on last", groups: []int{2}, keys: []tea.KeyMsg{keyDown, keyRight, keyRight}, want: "s1"},
+		{name: "arrows ignored without selection", groups: []int{7}, keys: []tea.KeyMsg{keyRight, keyLeft}},
+		{name: "tab past last unselects", groups: []int{2}, keys: []tea.KeyMsg{keyTab, keyTab, keyTab}},
+		{
+			name:   "down skips group header",
+			groups: []int{2, 2},
+			keys:   []tea.KeyMsg{keyDown, keyDown},
+			want:   "s2",
+		},
+		{
+			name:   "up skips group header",
+			groups: []int{2, 2},
+			keys:   []tea.KeyMsg{keyDown, keyDown, keyRight, keyUp},
+			want:   "s1",
+		},
+		{
+			name:   "page down moves a page",
+			groups: []int{20},
+			keys:   []tea.KeyMsg{keyDown, keyRight, keyPageDown},
+			want:   "s10",
+		},
+		{
+			name:   "page down stops at last row",
+			groups: []int{20},
+			keys:   []tea.KeyMsg{keyDown, keyPageDown, keyPageDown, keyPageDown},
+			want:   "s18",
+		},
+		{
+			name:   "page up moves a page",
+			groups: []int{20},
+			keys:   []tea.KeyMsg{keyDown, keyPageDown, keyPageDown, keyPageUp},
+			want:   "s9",
+		},
+		{
+			name:   "page up stops at first row",
+			groups: []int{20},
+			keys:   []tea.KeyMsg{keyDown, keyDown, keyRight, keyPageUp},
+			want:   "s1",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := newTestGrid(t, 3, testSuggestions(test.groups...))
+			for _, key := range test.keys {
+				m.Update(key)
+			}
+			if got := selected(m); got != test.want {
+				t.Errorf("selected = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGridScrolling(t *testing.T) {
+	tests := []struct {
+		name string
+		// groups is the number of suggestions in each group
+		groups          []int
+		keys            []tea.KeyMsg
+		availableHeight int
+		scrollPosition  int
+		visibleRows     int
+	}{
+		{name: "fits", groups: []int{6}, keys: []tea.KeyMsg{keyDown, keyDown}, visibleRows: 2},
+		{
+			name:           "scrolls down",
+			groups:         []int{20},
+			keys:           []tea.KeyMsg{keyDown, keyDown, keyDown, keyDown},
+			scrollPosition: 1,
+			visibleRows:    3,
+		},
+		{
+			name:           "scrolls back up",
+			groups:         []int{20},
+			keys:           []tea.KeyMsg{keyDown, keyDown, keyDown, keyDown, keyUp, keyUp, keyUp},
+			scrollPosition: 0,
+			visibleRows:    3,
+		},
+		{
+			name:           "keeps header in view when scrolling up",
+			groups:         []int{3, 9},
+			keys:           []tea.KeyMsg{keyDown, keyDown, keyDown, keyDown, keyUp, keyUp},
+			scrollPosition: 2,
+			visibleRows:    3,
+		},
+		{
+			name:            "available height limits rows",
+			groups:          []int{20},
+			keys:            []tea.KeyMsg{keyDown, keyDown},
+			availableHeight: 1,
+			scrollPosition:  1,
+			visibleRows:     1,
+		},
+		{
+			name:            "available height larger than max",
+			groups:          []int{20},
+			keys:            []tea.KeyMsg{keyDown},
+			availableHeight: 10,
+			visibleRows:     3,
+		},
+		{
+			name:            "page down uses available height",
+			groups:          []int{20},
+			keys:            []tea.KeyMsg{keyDown, keyPageDown},
+			availableHeight: 2,
+			scrollPosition:  1,
+			visibleRows:     2,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := newTestGrid(t, 3, testSuggestions(test.groups...))
+			m.SetAvailableHeight(test.availableHeight)
+			for _, key := range test.keys {
+				m.Update(key)
+			}
+			if got := m.ScrollPosition(); got != test.scrollPosition {
+				t.Errorf("scroll position = %d, want %d", got, test.scrollPosition)
+			}
+			if got := len(m.Window().Visible(m.Rows())); got != test.visibleRows {
+				t.Errorf("visible rows = %d, want %d", got, test.visibleRows)
+			}
+			if lines := strings.Split(ansi.Strip(m.Render(0)), "\n"); len(lines) != test.visibleRows {
+				t.Errorf("rendered lines = %q, want %d lines", lines, test.visibleRows)
+			}
+		})
+	}
+}
+
+func TestGridAvailableHeightKeepsSelectionVisible(t *testing.T) {
+	m := newTestGrid(t, 3, testSuggestions(20))
+	for range 3 {
+		m.Update(keyDown)
+	}
+	// s6 is in the third row
+	if got := m.ScrollPosition(); got != 0 {
+		t.Fatalf("scroll position = %d, want 0", got)
+	}
+	m.SetAvailableHeight(1)
+	if got := m.ScrollPosition(); got != 2 {
+		t.Errorf("scroll position = %d, want 2", got)
+	}
+	m.SetAvailableHeight(0)
+	if got := m.ScrollPosition(); got != 2 {
+		t.Errorf("scroll position after removing the limit = %d, want 2", got)
+	}
+}
+
+func TestGridResizeKeepsSelectionVisible(t *testing.T) {
+	m := newTestGrid(t, 3, testSuggestions(9))
+	m.Update(keyDown)
+	for range 8 {
+		m.Update(keyRight)
+	}
+	if got := selected(m); got != "s8" {
+		t.Fatalf("selected = %q, want s8", got)
+	}
+	// One column puts s8 in the last of nine rows
+	m.Update(tea.WindowSizeMsg{Width: 1, Height: 24})
+	if got := m.ScrollPosition(); got != 6 {
+		t.Errorf("scroll position = %d, want 6", got)
+	}
+	if got := selected(m); got != "s8" {
+		t.Errorf("selected = %q, want s8", got)
+	}
+}
+
+func TestGridGroupHeaders(t *testing.T) {
+	m := newTestGrid(t, 3, testSuggestions(4, 2))
+	want := []string{"group0", "s0 s1 s2", "s3", "group1", "s4 s5"}
+	rows := m.Rows()
+	if len(rows) != len(want) {
+		t.Fatalf("rows = %d, want %d", len(rows), len(want))
+	}
+	for i, row := range rows {
+		got := row.Header
+		if !row.IsHeader() {
+			got = ""
+			for j, index := range row.Indexes {
+				if j > 0 {
+					got += " "
+				}
+				got += m.Suggestions()[index].Text
+			}
+		}
+		if got != want[i] {
+			t.Errorf("row %d = %q, want %q", i, got, want[i])
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package grid
+
+import "github.com/aschey/bubbleprompt/suggestion"
+
+type Option[T any] func(model *Model[T])
+
+// WithMaxSuggestions sets the maximum number of rows that are shown before the grid scrolls.
+func WithMaxSuggestions[T any](maxSuggestions int) Option[T] {
+	return func(model *Model[T]) {
+		model.SetMaxSuggestions(maxSuggestions)
+	}
+}
+
+func WithSelectionIndicator[T any](indicator string) Option[T] {
+	return func(model *Model[T]) {
+		model.SetSelectionIndicator(indicator)
+	}
+}
+
+func WithFormatters[T any](formatters suggestion.Formatters) Option[T] {
+	return func(model *Model[T]) {
+		model.SetFormatters(formatters)
+	}
+}
+
+func WithKeyMap[T any](keyMap suggestion.KeyMap) Option[T] {
+	return func(model *Model[T]) {
+		model.SetKeyMap(keyMap)
+	}
+}
+
+// WithGridKeyMap sets the keys used to move between columns and pages.
+func WithGridKeyMap[T any](keyMap KeyMap) Option[T] {
+	return func(model *Model[T]) {
+		model.SetGridKeyMap(keyMap)
+	}
+}
@@ -0,0 +1,34 @@
+package suggestion
+
+import "slices"
+
+// GroupSuggestions returns the suggestions ordered so that suggestions in the same group are next to each other.
+// Groups are ordered by the first suggestion in each group and the suggestions within each group keep their order.
+func GroupSuggestions[T any](suggestions []Suggestion[T]) []Suggestion[T] {
+	groups := []string{}
+	for _, suggestion := range suggestions {
+		if !slices.Contains(groups, suggestion.Group) {
+			groups = append(groups, suggestion.Group)
+		}
+	}
+	if len(groups) < 2 {
+		return suggestions
+	}
+
+	grouped := make([]Suggestion[T], 0, len(suggestions))
+	for _, group := range groups {
+		for _, suggestion := range suggestions {
+			if suggestion.Group == group {
+				grouped = append(grouped, suggestion)
+			}
+		}
+	}
+	return grouped
+}
+
+// HasGroupHeader returns whether a group header is shown above the suggestion at the given index.
+// Headers are shown whenever the group changes, except for suggestions that don't have a group.
+func HasGroupHeader[T any](suggestions []Suggestion[T], index int) bool {
+	group := suggestions[index].Group
+	return group != "" && (index == 0 || suggestions[index-1].Group != group)
+}
@@ -0,0 +1,30 @@
+package suggestion_test
+
+import (
+	"fmt"
+
+	"github.com/aschey/bubbleprompt/suggestion"
+)
+
+func ExampleGroupSuggestions() {
+	suggestions := suggestion.GroupSuggestions([]suggestion.Suggestion[any]{
+		{Text: "run", Group: "Commands"},
+		{Text: "--verbose", Group: "Flags"},
+		{Text: "build", Group: "Commands"},
+		{Text: "--quiet", Group: "Flags"},
+	})
+
+	for i, s := range suggestions {
+		if suggestion.HasGroupHeader(suggestions, i) {
+			fmt.Println(s.Group)
+		}
+		fmt.Println("  " + s.Text)
+	}
+	// Output:
+	// Commands
+	//   run
+	//   build
+	// Flags
+	//   --verbose
+	//   --quiet
+}
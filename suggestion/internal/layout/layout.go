@@ -0,0 +1,181 @@
+// Package layout contains the row layout, scrolling and selection logic that's shared by the suggestion managers.
+package layout
+
+import (
+	"math"
+	"strings"
+
+	"github.com/aschey/bubbleprompt/suggestion"
+	"github.com/mattn/go-runewidth"
+)
+
+// Row is a row in the suggestion list, which is either a group header or a list of suggestions.
+type Row struct {
+	Header string
+	// Indexes contains the index of each suggestion in the row or nil for headers
+	Indexes []int
+}
+
+// IsHeader returns whether the row is a group header.
+func (r Row) IsHeader() bool {
+	return r.Indexes == nil
+}
+
+// Rows lays out the suggestions in rows with at most the given number of columns.
+// Each group starts on a new row below a header with the group's name.
+func Rows[T any](suggestions []suggestion.Suggestion[T], columns int) []Row {
+	rows := []Row{}
+	for i, cur := range suggestions {
+		if suggestion.HasGroupHeader(suggestions, i) {
+			rows = append(rows, Row{Header: cur.Group})
+		}
+		last := len(rows) - 1
+		if last < 0 || rows[last].IsHeader() || len(rows[last].Indexes) >= columns ||
+			suggestions[i-1].Group != cur.Group {
+			rows = append(rows, Row{Indexes: []int{i}})
+		} else {
+			rows[last].Indexes = append(rows[last].Indexes, i)
+		}
+	}
+	return rows
+}
+
+// Position returns the row and column of the suggestion at the index or -1 if it isn't in the rows.
+func Position(rows []Row, index int) (int, int) {
+	for i, row := range rows {
+		for j, rowIndex := range row.Indexes {
+			if rowIndex == index {
+				return i, j
+			}
+		}
+	}
+	return -1, -1
+}
+
+// Move returns the index of the suggestion that's the given number of rows above or below the suggestion at the index,
+// staying in the same column if possible. Group headers are skipped.
+// If there are fewer rows in that direction, the suggestion in the last row that's available is returned.
+// It returns -1 if there are no more rows in that direction.
+func Move(rows []Row, index int, delta int) int {
+	row, column := Position(rows, index)
+	if row < 0 || delta == 0 {
+		return -1
+	}
+	step := 1
+	if delta < 0 {
+		step = -1
+	}
+
+	target := row
+	for i := row + step; i >= 0 && i < len(rows) && delta != 0; i += step {
+		if !rows[i].IsHeader() {
+			target = i
+			delta -= step
+		}
+	}
+	if target == row {
+		return -1
+	}
+	indexes := rows[target].Indexes
+	return indexes[min(column, len(indexes)-1)]
+}
+
+// Window tracks which rows are scrolled into view.
+type Window struct {
+	// MaxRows is the maximum number of rows that are shown before the list scrolls.
+	MaxRows int
+	// AvailableHeight limits the number of rows when there's less space than MaxRows. It's ignored if it's 0 or less.
+	AvailableHeight int
+	// ScrollPosition is the index of the first visible row.
+	ScrollPosition int
+	// PrevScroll is the scroll position before the last time the selection changed.
+	PrevScroll int
+}
+
+// Reset scrolls back to the top.
+func (w *Window) Reset() {
+	w.ScrollPosition = 0
+	w.PrevScroll = 0
+}
+
+// MaxVisibleRows returns the maximum number of rows that can be shown at once.
+func (w Window) MaxVisibleRows() int {
+	if w.AvailableHeight > 0 {
+		return min(w.MaxRows, w.AvailableHeight)
+	}
+	return w.MaxRows
+}
+
+// Height returns the number of rows that are shown out of the total number of rows.
+func (w Window) Height(totalRows int) int {
+	return min(totalRows, w.MaxVisibleRows())
+}
+
+// Visible returns the rows that are scrolled into view.
+func (w Window) Visible(rows []Row) []Row {
+	return rows[w.ScrollPosition : w.ScrollPosition+w.Height(len(rows))]
+}
+
+// ScrollTo scrolls the window so the row is visible.
+// The group's header is kept in view when scrolling up to the first row in the group.
+func (w *Window) ScrollTo(rows []Row, row int) {
+	if row < 0 {
+		return
+	}
+	top := row
+	if top > 0 && rows[top-1].IsHeader() {
+		top--
+	}
+	if top < w.ScrollPosition {
+		w.ScrollPosition = top
+	}
+	if row >= w.ScrollPosition+w.MaxVisibleRows() {
+		w.ScrollPosition = row - w.MaxVisibleRows() + 1
+	}
+	// Resizing can leave fewer rows below the scroll position than will fit in the window
+	w.ScrollPosition = max(min(w.ScrollPosition, len(rows)-w.Height(len(rows))), 0)
+}
+
+// ScrollbarBounds returns the start and end of the scrollbar's thumb relative to the first visible row.
+func (w Window) ScrollbarBounds(totalRows int) (int, int) {
+	windowHeight := w.Height(totalRows)
+	// The zero-based index of the first row that will be shown when the content is scrolled to the bottom
+	lastSegmentStart := totalRows - windowHeight
+	scrollbarHeight := int(math.Max(float64(windowHeight-lastSegmentStart), 1))
+	scrollbarPos := float64(
+		w.ScrollPosition,
+	) * (float64(windowHeight-scrollbarHeight) / float64(lastSegmentStart))
+
+	// If scrolling up, use ceiling operation to ensure the scrollbar is only at the top when the first row is shown
+	// otherwise use floor operation
+	var scrollbarTop int
+	if w.PrevScroll > w.ScrollPosition {
+		scrollbarTop = int(math.Ceil(scrollbarPos))
+	} else {
+		scrollbarTop = int(math.Floor(scrollbarPos))
+	}
+
+	return scrollbarTop, scrollbarTop + scrollbarHeight
+}
+
+// Scrollbar returns the scrollbar for the row at the given position in the window,
+// or an empty string if all of the rows fit in the window.
+func (w Window) Scrollbar(totalRows int, position int, scrollbar string, scrollbarThumb string) string {
+	if totalRows <= w.MaxVisibleRows() {
+		return ""
+	}
+	start, end := w.ScrollbarBounds(totalRows)
+	if start <= position && position < end {
+		return scrollbarThumb
+	}
+	return scrollbar
+}
+
+// RenderGroupHeader renders a group header that's indented to line up with the suggestions
+// after the selection indicator and fills the remaining width.
+func RenderGroupHeader(formatters suggestion.Formatters, header string, indicator string, width int) string {
+	indent := runewidth.StringWidth(indicator)
+	width = max(width-indent, 0)
+	return strings.Repeat(" ", indent) +
+		formatters.GroupHeader.Width(width).Render(runewidth.Truncate(header, width, "…"))
+}
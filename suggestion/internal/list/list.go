@@ -0,0 +1,351 @@
+// Package list contains the suggestion state, selection and scrolling that's shared by the suggestion managers.
+package list
+
+import (
+	"github.com/aschey/bubbleprompt/input"
+	"github.com/aschey/bubbleprompt/suggestion"
+	"github.com/aschey/bubbleprompt/suggestion/internal/layout"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const defaultMaxSuggestions = 6
+
+// Arrangement contains the parts of a suggestion manager that depend on how the suggestions are laid out.
+type Arrangement struct {
+	// Columns returns the maximum number of suggestions in each row.
+	Columns func() int
+	// Navigate moves the selection for any key other than [suggestion.KeyMap.Complete].
+	// It returns false if the key doesn't change the selection.
+	Navigate func(msg tea.KeyMsg) bool
+	// IsNavigationKey reports whether the key moves the selection in addition to the keys in the
+	// [suggestion.KeyMap]. It's optional.
+	IsNavigationKey func(msg tea.KeyMsg) bool
+}
+
+// Base implements the parts of a [suggestion.Manager] that don't depend on the layout.
+// Suggestion managers embed it and add their own layout, rendering and navigation.
+type Base[T any] struct {
+	textInput          input.Input[T]
+	arrangement        Arrangement
+	suggestions        []suggestion.Suggestion[T]
+	lastKeyMsg         tea.KeyMsg
+	showSuggestions    bool
+	window             layout.Window
+	selectedKey        *string
+	prevRunes          []rune
+	sequenceNumber     int
+	selectionIndicator string
+	scrollbar          string
+	scrollbarThumb     string
+	formatters         suggestion.Formatters
+	keyMap             suggestion.KeyMap
+	err                error
+}
+
+// New creates the shared state for a suggestion manager that lays out its suggestions with the given arrangement.
+func New[T any](textInput input.Input[T], arrangement Arrangement) *Base[T] {
+	return &Base[T]{
+		textInput:          textInput,
+		arrangement:        arrangement,
+		window:             layout.Window{MaxRows: defaultMaxSuggestions},
+		showSuggestions:    false,
+		selectionIndicator: "",
+		scrollbar:          " ",
+		scrollbarThumb:     " ",
+		sequenceNumber:     -1,
+		formatters:         suggestion.DefaultFormatters(),
+		keyMap:             suggestion.DefaultKeyMap(),
+		// Need to set the previous text to something in order to force the initial render
+		prevRunes: []rune(" "),
+	}
+}
+
+func (m *Base[T]) Init() tea.Cmd {
+	// Since the user hasn't typed anything on init, call the completer with empty text
+	return m.ResetSuggestions()
+}
+
+func (m *Base[T]) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		// The number of columns may depend on the width, so the selection may have moved to a different row
+		if m.IsSuggestionSelected() {
+			m.scrollToSelected()
+		}
+	case suggestion.SuggestionMsg[T]:
+		if m.sequenceNumber < msg.SequenceNumber {
+			m.sequenceNumber = msg.SequenceNumber
+			if msg.Suggestions == nil {
+				m.suggestions = []suggestion.Suggestion[T]{}
+			} else {
+				m.suggestions = suggestion.GroupSuggestions(msg.Suggestions)
+			}
+
+			m.err = msg.Err
+			// Selection is out of range of the current view or the key is no longer present
+			if m.window.ScrollPosition > len(m.Rows())-1 || m.SelectedSuggestion() == nil {
+				m.UnselectSuggestion()
+			}
+		}
+	case suggestion.PeriodicCompleterMsg:
+		if !m.canUpdateSuggestions() {
+			return suggestion.PeriodicCompleter(msg.NextTrigger)
+		}
+		return tea.Batch(m.forceUpdateSuggestions(), suggestion.PeriodicCompleter(msg.NextTrigger))
+	case suggestion.OneShotCompleterMsg:
+		if !m.canUpdateSuggestions() {
+			return nil
+		}
+		return m.forceUpdateSuggestions()
+	case tea.KeyMsg:
+		m.lastKeyMsg = msg
+		if key.Matches(msg, m.keyMap.Complete) {
+			// Tab suggestion may have changed text so reset previous value
+			m.prevRunes = []rune("")
+			m.NextSuggestion()
+		} else if !m.arrangement.Navigate(msg) {
+			return nil
+		}
+		return m.updateIfUnselected()
+	}
+	return nil
+}
+
+func (m *Base[T]) updateIfUnselected() tea.Cmd {
+	if m.IsSuggestionSelected() {
+		// Set the input to the suggestion's selected text
+		return nil
+	}
+	// Need to update suggestions since we changed the text and the cursor position
+	return m.UpdateSuggestions()
+}
+
+func (m Base[T]) canUpdateSuggestions() bool {
+	runes := m.textInput.Runes()
+	if len(m.textInput.SuggestionRunes(runes[:m.textInput.CursorIndex()])) == 0 {
+		return true
+	}
+	if m.arrangement.IsNavigationKey != nil && m.arrangement.IsNavigationKey(m.lastKeyMsg) {
+		return false
+	}
+	return !m.keyMap.Matches(m.lastKeyMsg)
+}
+
+func (m Base[T]) ScrollbarBounds() (int, int) {
+	// Headers take up space in the list too, so the scrollbar is based on the number of rows
+	return m.window.ScrollbarBounds(len(m.Rows()))
+}
+
+func (m *Base[T]) SetShowSuggestions(showSuggestions bool) {
+	m.showSuggestions = showSuggestions
+}
+
+func (m *Base[T]) UpdateSuggestions() tea.Cmd {
+	return m.updateSuggestionsCmd(false)
+}
+
+func (m *Base[T]) forceUpdateSuggestions() tea.Cmd {
+	return m.updateSuggestionsCmd(true)
+}
+
+func (m *Base[T]) updateSuggestionsCmd(forceUpdate bool) tea.Cmd {
+	runes := m.textInput.Runes()
+	cursorPos := m.textInput.CursorIndex()
+
+	runesBeforeCursor := runes
+	if cursorPos < len(runes) {
+		runesBeforeCursor = runes[:cursorPos]
+	}
+
+	// No need to queue another update if the text hasn't changed
+	// Don't trim whitespace here because cursor location affects suggestions
+	if !forceUpdate && string(runesBeforeCursor) == string(m.prevRunes) {
+		return nil
+	}
+
+	m.prevRunes = runesBeforeCursor
+
+	return suggestion.Complete
+}
+
+func (m *Base[T]) ResetSuggestions() tea.Cmd {
+	m.prevRunes = []rune("")
+	return suggestion.Complete
+}
+
+func (m *Base[T]) UnselectSuggestion() {
+	m.selectedKey = nil
+	m.window.Reset()
+	m.textInput.OnSuggestionUnselected()
+}
+
+func (m *Base[T]) ClearSuggestions() {
+	m.UnselectSuggestion()
+	m.suggestions = []suggestion.Suggestion[T]{}
+}
+
+func (m *Base[T]) SelectSuggestion(suggestion suggestion.Suggestion[T]) {
+	m.selectedKey = suggestion.Key()
+	m.textInput.OnSuggestionChanged(suggestion)
+}
+
+func (m *Base[T]) IsSuggestionSelected() bool {
+	return m.selectedKey != nil
+}
+
+// NextSuggestion selects the next suggestion, continuing on the next row at the end of each row.
+func (m *Base[T]) NextSuggestion() {
+	if len(m.suggestions) == 0 {
+		return
+	}
+	if index := m.SelectedIndex(); index < len(m.suggestions)-1 {
+		m.SelectIndex(index + 1)
+	} else {
+		m.UnselectSuggestion()
+	}
+}
+
+// PreviousSuggestion selects the previous suggestion, continuing on the previous row at the start of each row.
+func (m *Base[T]) PreviousSuggestion() {
+	if len(m.suggestions) == 0 {
+		return
+	}
+	if index := m.SelectedIndex(); index > 0 {
+		m.SelectIndex(index - 1)
+	} else {
+		m.UnselectSuggestion()
+	}
+}
+
+// SelectIndex selects the suggestion at the given index and scrolls it into view.
+func (m *Base[T]) SelectIndex(index int) {
+	m.window.PrevScroll = m.window.ScrollPosition
+	m.SelectSuggestion(m.suggestions[index])
+	m.scrollToSelected()
+}
+
+// scrollToSelected scrolls the list so the row with the selected suggestion is visible.
+// Headers can't be selected, so the list may need to scroll past one to show the next suggestion.
+func (m *Base[T]) scrollToSelected() {
+	rows := m.Rows()
+	row, _ := layout.Position(rows, m.SelectedIndex())
+	m.window.ScrollTo(rows, row)
+}
+
+func (m *Base[T]) SelectedIndex() int {
+	if m.IsSuggestionSelected() {
+		for i, suggestion := range m.suggestions {
+			if *suggestion.Key() == *m.selectedKey {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func (m *Base[T]) SelectedSuggestion() *suggestion.Suggestion[T] {
+	if m.IsSuggestionSelected() {
+		for _, suggestion := range m.suggestions {
+			if *suggestion.Key() == *m.selectedKey {
+				return &suggestion
+			}
+		}
+	}
+	return nil
+}
+
+func (m *Base[T]) EnableScrollbar() {
+	m.scrollbar = " "
+	m.scrollbarThumb = " "
+}
+
+func (m *Base[T]) DisableScrollbar() {
+	m.scrollbar = ""
+	m.scrollbarThumb = ""
+}
+
+func (m *Base[T]) MaxSuggestions() int {
+	return m.window.MaxRows
+}
+
+func (m *Base[T]) SetMaxSuggestions(maxSuggestions int) {
+	m.window.MaxRows = maxSuggestions
+}
+
+func (m *Base[T]) SetAvailableHeight(height int) {
+	m.window.AvailableHeight = height
+	if m.IsSuggestionSelected() {
+		// Keep the selected suggestion in view
+		m.scrollToSelected()
+	}
+}
+
+func (m *Base[T]) SelectionIndicator() string {
+	return m.selectionIndicator
+}
+
+func (m *Base[T]) SetSelectionIndicator(selectionIndicator string) {
+	m.selectionIndicator = selectionIndicator
+}
+
+// KeyMap returns the keys used to move through the suggestions.
+func (m *Base[T]) KeyMap() suggestion.KeyMap {
+	return m.keyMap
+}
+
+func (m *Base[T]) SetKeyMap(keyMap suggestion.KeyMap) {
+	m.keyMap = keyMap
+}
+
+func (m *Base[T]) Formatters() suggestion.Formatters {
+	return m.formatters
+}
+
+func (m *Base[T]) SetFormatters(formatters suggestion.Formatters) {
+	m.formatters = formatters
+}
+
+func (m *Base[T]) Suggestions() []suggestion.Suggestion[T] {
+	if m.showSuggestions {
+		return m.suggestions
+	}
+	return []suggestion.Suggestion[T]{}
+}
+
+// Rows lays out the suggestions in rows with a header row above the first suggestion in each group.
+func (m Base[T]) Rows() []layout.Row {
+	return layout.Rows(m.Suggestions(), m.arrangement.Columns())
+}
+
+// Window returns the rows that are scrolled into view.
+func (m Base[T]) Window() layout.Window {
+	return m.window
+}
+
+// VisibleSuggestions returns the suggestions that are currently scrolled into view, not including group headers.
+func (m *Base[T]) VisibleSuggestions() []suggestion.Suggestion[T] {
+	visibleSuggestions := []suggestion.Suggestion[T]{}
+	for _, row := range m.window.Visible(m.Rows()) {
+		for _, index := range row.Indexes {
+			visibleSuggestions = append(visibleSuggestions, m.suggestions[index])
+		}
+	}
+	return visibleSuggestions
+}
+
+func (m *Base[T]) Error() error {
+	return m.err
+}
+
+func (m *Base[T]) ScrollPosition() int {
+	return m.window.ScrollPosition
+}
+
+func (m *Base[T]) Scrollbar() string {
+	return m.scrollbar
+}
+
+func (m *Base[T]) ScrollbarThumb() string {
+	return m.scrollbarThumb
+}
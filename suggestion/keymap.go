@@ -0,0 +1,28 @@
+package suggestion
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// KeyMap defines the keys used to cycle through the suggestions.
+type KeyMap struct {
+	// Complete selects the next suggestion, wrapping back to the input after the last one.
+	Complete key.Binding
+	Next     key.Binding
+	Previous key.Binding
+}
+
+// DefaultKeyMap returns the default suggestion key bindings.
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Complete: key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "complete")),
+		Next:     key.NewBinding(key.WithKeys("down"), key.WithHelp("↓", "next suggestion")),
+		Previous: key.NewBinding(key.WithKeys("up"), key.WithHelp("↑", "previous suggestion")),
+	}
+}
+
+// Matches reports whether the message matches any of the suggestion key bindings.
+func (k KeyMap) Matches(msg tea.KeyMsg) bool {
+	return key.Matches(msg, k.Complete, k.Next, k.Previous)
+}
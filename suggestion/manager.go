@@ -37,3 +37,15 @@ type Manager[T any] interface {
 	SetFormatters(formatters Formatters)
 	SetShowSuggestions(showSuggestions bool)
 }
+
+// AvailableHeightSetter is implemented by managers that can limit the number of rows that are shown
+// when there's less space than MaxSuggestions.
+type AvailableHeightSetter interface {
+	// SetAvailableHeight limits the number of rows that are shown. A height of 0 or less removes the limit.
+	SetAvailableHeight(height int)
+}
+
+// KeyMapSetter is implemented by managers that allow the keys used to move through the suggestions to be customized.
+type KeyMapSetter interface {
+	SetKeyMap(keyMap KeyMap)
+}
@@ -0,0 +1,28 @@
+package suggestion
+
+import "github.com/charmbracelet/lipgloss"
+
+// OutputFormatter handles styling for executors that render structured output
+// such as tables, trees, and JSON or YAML documents.
+type OutputFormatter struct {
+	// TableHeader handles styling for the header row of a table.
+	TableHeader lipgloss.Style
+	// TableCell handles styling for the cells in the body of a table.
+	TableCell lipgloss.Style
+	// TableBorder handles styling for the borders of a table.
+	TableBorder lipgloss.Style
+	// TreeBranch handles styling for the lines that connect the nodes of a tree.
+	TreeBranch lipgloss.Style
+	// TreeLabel handles styling for the labels of the nodes in a tree.
+	TreeLabel lipgloss.Style
+	// DataKey handles styling for object keys in JSON and YAML documents.
+	DataKey lipgloss.Style
+	// DataString handles styling for string values in JSON and YAML documents.
+	DataString lipgloss.Style
+	// DataNumber handles styling for number values in JSON and YAML documents.
+	DataNumber lipgloss.Style
+	// DataLiteral handles styling for booleans and null values in JSON and YAML documents.
+	DataLiteral lipgloss.Style
+	// DataPunctuation handles styling for brackets, commas, and other punctuation in JSON and YAML documents.
+	DataPunctuation lipgloss.Style
+}
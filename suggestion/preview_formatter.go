@@ -0,0 +1,11 @@
+package suggestion
+
+import "github.com/charmbracelet/lipgloss"
+
+// PreviewFormatter handles styling for the pane that shows the selected suggestion's preview.
+type PreviewFormatter struct {
+	// Pane handles styling for the pane's border and padding.
+	Pane lipgloss.Style
+	// Status handles styling for the line that shows the scroll position when the preview doesn't fit in the pane.
+	Status lipgloss.Style
+}
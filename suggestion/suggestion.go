@@ -11,8 +11,18 @@ type Suggestion[T any] struct {
 	Text           string
 	SuggestionText string
 	Description    string
-	Metadata       T
-	CursorOffset   int
+	// Group is the section the suggestion is shown in, such as "Commands" or "Flags".
+	// Suggestions in the same group are shown together below a header with the group's name.
+	Group string
+	// MatchedIndexes contains the indexes of the runes in the suggestion text that matched the search.
+	// Filterers set this so the matched characters can be highlighted.
+	MatchedIndexes []int
+	// Preview returns long-form content such as usage information, documentation, or file contents
+	// that's shown in a pane next to the suggestions while the suggestion is selected.
+	// It's only called when the suggestion is selected, so the content can be expensive to generate.
+	Preview      func() string
+	Metadata     T
+	CursorOffset int
 }
 
 func (s Suggestion[T]) GetSuggestionText() string {
@@ -30,7 +40,13 @@ func (s Suggestion[T]) Render(
 	scrollbar string,
 	indicator string,
 ) string {
-	name := formatters.Name.Format(s.GetSuggestionText(), maxNameLen, selected)
+	name := formatters.Name.FormatMatches(
+		s.GetSuggestionText(),
+		maxNameLen,
+		selected,
+		s.MatchedIndexes,
+		formatters.Match,
+	)
 	selectedIndicator := formatters.SelectedIndicator.Render(indicator)
 	if !selected {
 		selectedIndicator = strings.Repeat(" ", runewidth.StringWidth(indicator))
@@ -1,6 +1,7 @@
 package suggestion
 
 import (
+	"github.com/aschey/bubbleprompt/internal"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/mattn/go-runewidth"
 )
@@ -8,9 +9,31 @@ import (
 type SuggestionText struct {
 	Style         lipgloss.Style
 	SelectedStyle lipgloss.Style
+	// Markdown renders the text as inline Markdown, such as **bold** or `code`.
+	Markdown bool
+}
+
+// Width returns the width of the text once it's rendered, not including padding.
+func (t SuggestionText) Width(text string) int {
+	if t.Markdown {
+		return lipgloss.Width(internal.RenderInlineMarkdown(text, t.Style))
+	}
+	return runewidth.StringWidth(text)
 }
 
 func (t SuggestionText) Format(text string, maxLen int, selected bool) string {
+	return t.FormatMatches(text, maxLen, selected, nil, lipgloss.NewStyle())
+}
+
+// FormatMatches formats the text like [SuggestionText.Format] and highlights the runes at the matched indexes
+// with the match style. Matches aren't highlighted in Markdown text since the indexes refer to the unrendered text.
+func (t SuggestionText) FormatMatches(
+	text string,
+	maxLen int,
+	selected bool,
+	matchedIndexes []int,
+	matchStyle lipgloss.Style,
+) string {
 	style := t.Style
 
 	if selected {
@@ -26,9 +49,17 @@ func (t SuggestionText) Format(text string, maxLen int, selected bool) string {
 		leftPadding = 1
 	}
 
+	textWidth := t.Width(text)
+	switch {
+	case t.Markdown:
+		text = internal.RenderInlineMarkdown(text, style)
+	case len(matchedIndexes) > 0:
+		// Keep the background and other attributes of the surrounding text
+		text = lipgloss.StyleRunes(text, matchedIndexes, matchStyle.Inherit(style), style)
+	}
 	formattedText := style.
 		PaddingLeft(leftPadding).
-		PaddingRight(maxLen - runewidth.StringWidth(text) + 1).
+		PaddingRight(maxLen - textWidth + 1).
 		Render(text)
 	return formattedText
 }
@@ -0,0 +1,88 @@
+package prompt
+
+import (
+	"fmt"
+	"os/exec"
+	"reflect"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// teaRuntime recognizes the messages that are handled by the bubbletea runtime instead of the model.
+// Executor messages are routed by their type, so a bubbletea upgrade that changes these types would silently
+// send runtime messages to jobs instead. The types are checked when the package is initialized to catch that early.
+var teaRuntime = newRuntimeMsgs(runtimeCmds())
+
+// runtimeCmds returns a command that creates each message that's handled by the bubbletea runtime.
+// The types are taken from the commands that create them since most of them aren't exported.
+func runtimeCmds() []tea.Cmd {
+	return []tea.Cmd{
+		tea.ClearScreen,
+		tea.EnterAltScreen,
+		tea.ExitAltScreen,
+		tea.EnableMouseCellMotion,
+		tea.EnableMouseAllMotion,
+		tea.DisableMouse,
+		tea.HideCursor,
+		tea.ShowCursor,
+		tea.EnableBracketedPaste,
+		tea.DisableBracketedPaste,
+		tea.EnableReportFocus,
+		tea.DisableReportFocus,
+		tea.ClearScrollArea,
+		tea.SyncScrollArea(nil, 0, 0),
+		tea.ScrollUp(nil, 0, 0),
+		tea.ScrollDown(nil, 0, 0),
+		tea.Println(),
+		tea.SetWindowTitle(""),
+		tea.WindowSize(),
+		tea.ExecProcess(&exec.Cmd{}, nil),
+		tea.Suspend,
+		tea.Interrupt,
+	}
+}
+
+type runtimeMsgs struct {
+	batchType    reflect.Type
+	sequenceType reflect.Type
+	types        map[reflect.Type]struct{}
+}
+
+func newRuntimeMsgs(cmds []tea.Cmd) runtimeMsgs {
+	batchType := reflect.TypeOf(tea.BatchMsg{})
+	// The message created by tea.Sequence isn't exported, but it's a list of commands just like tea.BatchMsg
+	sequenceType := reflect.TypeOf(tea.Sequence()())
+	if sequenceType == nil || sequenceType == batchType || !sequenceType.ConvertibleTo(batchType) {
+		panic(fmt.Sprintf("bubbleprompt: unsupported tea.Sequence message type %v", sequenceType))
+	}
+
+	teaPkgPath := batchType.PkgPath()
+	types := map[reflect.Type]struct{}{}
+	for _, cmd := range cmds {
+		msgType := reflect.TypeOf(cmd())
+		if msgType == nil || msgType.PkgPath() != teaPkgPath {
+			panic(fmt.Sprintf("bubbleprompt: unsupported bubbletea runtime message type %v", msgType))
+		}
+		if _, ok := types[msgType]; ok {
+			panic(fmt.Sprintf("bubbleprompt: bubbletea runtime message type %v is used by multiple commands", msgType))
+		}
+		types[msgType] = struct{}{}
+	}
+	return runtimeMsgs{batchType: batchType, sequenceType: sequenceType, types: types}
+}
+
+// handles returns whether the message is handled by the bubbletea runtime.
+func (r runtimeMsgs) handles(msg tea.Msg) bool {
+	_, ok := r.types[reflect.TypeOf(msg)]
+	return ok
+}
+
+// sequencedCmds returns the commands from the message created by [tea.Sequence].
+func (r runtimeMsgs) sequencedCmds(msg tea.Msg) ([]tea.Cmd, bool) {
+	value := reflect.ValueOf(msg)
+	if !value.IsValid() || value.Type() != r.sequenceType {
+		return nil, false
+	}
+	cmds, _ := value.Convert(r.batchType).Interface().(tea.BatchMsg)
+	return cmds, true
+}
@@ -0,0 +1,87 @@
+package prompt
+
+import (
+	"os/exec"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestTeaRuntimeMsgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		cmd     tea.Cmd
+		runtime bool
+	}{
+		{name: "clear screen", cmd: tea.ClearScreen, runtime: true},
+		{name: "enter alt screen", cmd: tea.EnterAltScreen, runtime: true},
+		{name: "exit alt screen", cmd: tea.ExitAltScreen, runtime: true},
+		{name: "mouse cell motion", cmd: tea.EnableMouseCellMotion, runtime: true},
+		{name: "mouse all motion", cmd: tea.EnableMouseAllMotion, runtime: true},
+		{name: "disable mouse", cmd: tea.DisableMouse, runtime: true},
+		{name: "hide cursor", cmd: tea.HideCursor, runtime: true},
+		{name: "show cursor", cmd: tea.ShowCursor, runtime: true},
+		{name: "enable bracketed paste", cmd: tea.EnableBracketedPaste, runtime: true},
+		{name: "disable bracketed paste", cmd: tea.DisableBracketedPaste, runtime: true},
+		{name: "enable report focus", cmd: tea.EnableReportFocus, runtime: true},
+		{name: "disable report focus", cmd: tea.DisableReportFocus, runtime: true},
+		{name: "clear scroll area", cmd: tea.ClearScrollArea, runtime: true},
+		{name: "sync scroll area", cmd: tea.SyncScrollArea([]string{"a"}, 1, 2), runtime: true},
+		{name: "scroll up", cmd: tea.ScrollUp([]string{"a"}, 1, 2), runtime: true},
+		{name: "scroll down", cmd: tea.ScrollDown([]string{"a"}, 1, 2), runtime: true},
+		{name: "println", cmd: tea.Println("line"), runtime: true},
+		{name: "printf", cmd: tea.Printf("%s", "line"), runtime: true},
+		{name: "window title", cmd: tea.SetWindowTitle("title"), runtime: true},
+		{name: "window size", cmd: tea.WindowSize(), runtime: true},
+		{name: "exec", cmd: tea.ExecProcess(exec.Command("true"), nil), runtime: true},
+		{name: "exec callback", cmd: tea.Exec(nil, func(error) tea.Msg { return nil }), runtime: true},
+		{name: "suspend", cmd: tea.Suspend, runtime: true},
+		{name: "interrupt", cmd: tea.Interrupt, runtime: true},
+		{name: "quit", cmd: tea.Quit},
+		{name: "batch", cmd: func() tea.Msg { return tea.BatchMsg{tea.ClearScreen} }},
+		{name: "sequence", cmd: tea.Sequence(tea.ClearScreen)},
+		{name: "key", cmd: func() tea.Msg { return tea.KeyMsg{Type: tea.KeyEnter} }},
+		{name: "window size message", cmd: func() tea.Msg { return tea.WindowSizeMsg{Width: 1, Height: 1} }},
+		{name: "custom", cmd: func() tea.Msg { return stopMsg{} }},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if runtime := teaRuntime.handles(test.cmd()); runtime != test.runtime {
+				t.Errorf("runtime message = %v, want %v", runtime, test.runtime)
+			}
+		})
+	}
+}
+
+func TestTeaRuntimeSequence(t *testing.T) {
+	cmds, ok := teaRuntime.sequencedCmds(tea.Sequence(tea.ClearScreen, tea.HideCursor)())
+	if !ok || len(cmds) != 2 {
+		t.Fatalf("sequenced commands = %d, %v, want 2, true", len(cmds), ok)
+	}
+	for _, msg := range []tea.Msg{nil, tea.BatchMsg{tea.ClearScreen}, tea.ClearScreen()} {
+		if _, ok := teaRuntime.sequencedCmds(msg); ok {
+			t.Errorf("%#v was treated as a sequence", msg)
+		}
+	}
+}
+
+func TestNewRuntimeMsgsPanics(t *testing.T) {
+	tests := []struct {
+		name string
+		cmds []tea.Cmd
+	}{
+		{name: "nil message", cmds: []tea.Cmd{func() tea.Msg { return nil }}},
+		{name: "type from another package", cmds: []tea.Cmd{func() tea.Msg { return stopMsg{} }}},
+		{name: "duplicate type", cmds: []tea.Cmd{tea.ClearScreen, tea.ClearScreen}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected a panic")
+				}
+			}()
+			newRuntimeMsgs(test.cmds)
+		})
+	}
+}
@@ -1,11 +1,16 @@
 package prompt
 
 import (
+	"context"
+	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/aschey/bubbleprompt/executor"
 	"github.com/aschey/bubbleprompt/input"
+	"github.com/aschey/bubbleprompt/renderer"
 	"github.com/aschey/bubbleprompt/suggestion"
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -13,14 +18,26 @@ func (m Model[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	var cmds []tea.Cmd
 
+	msg = m.foregroundJobMsg(msg)
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.Type {
-		// Ctrl+C should always shutdown the whole program regardless
-		// of what the executor is doing
-		case tea.KeyCtrlC:
-			shutdown = true
-			return m, tea.Quit
+		if key.Matches(msg, m.keyMap.Interrupt) {
+			// Interrupt cancels the history search or stops the executor if one is running.
+			// Otherwise, or if the executor is already being interrupted, it shuts down the whole program.
+			switch {
+			case m.modelState == searching:
+				cmd = m.finishHistorySearch(false)
+			case m.modelState == executing && !m.interrupted:
+				cmd = m.interruptExecutor()
+			default:
+				shutdown = true
+				return m, tea.Quit
+			}
+			// The key was handled here so it shouldn't be passed to anything else
+			return m, tea.Batch(cmd, m.render())
+		}
+		if m.modelState == executing && key.Matches(msg, m.keyMap.Background) {
+			return m, m.backgroundExecutor()
 		}
 	case rendererMsg:
 		// No need to switch renderers if they're the same type
@@ -28,6 +45,7 @@ func (m Model[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			currentHistory := m.renderer.GetHistory()
 
 			m.renderer = msg.renderer
+			m.applyRendererKeyMap()
 			m.renderer.Initialize(m.size)
 
 			if msg.retainHistory {
@@ -37,8 +55,18 @@ func (m Model[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case suggestion.CompleteMsg, suggestion.RefreshSuggestionsMessage[T]:
 		sequenceNumber := m.sequenceNumber
 		m.sequenceNumber++
+		// Any completion that's still running is outdated now
+		if m.cancelCompletion != nil {
+			m.cancelCompletion()
+		}
+		var ctx context.Context
+		ctx, m.cancelCompletion = context.WithCancel(context.Background())
 		cmds = append(cmds, func() tea.Msg {
-			filtered, err := m.inputHandler.Complete(m)
+			filtered, err := m.complete(ctx)
+			if ctx.Err() != nil {
+				// A newer completion was started so these results would be discarded anyway
+				return nil
+			}
 			return suggestion.SuggestionMsg[T]{Suggestions: filtered, SequenceNumber: sequenceNumber, Err: err}
 		})
 	case focusMsg:
@@ -60,14 +88,24 @@ func (m Model[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	cmds = append(cmds, cmd)
 
 	prevText := m.textInput.Runes()
-	cmd = m.textInput.OnUpdateStart(msg)
-	cmds = append(cmds, cmd)
+	if _, isKey := msg.(tea.KeyMsg); !isKey || m.modelState != searching {
+		// Keys typed while searching the history belong to the search query, not the input
+		cmd = m.textInput.OnUpdateStart(msg)
+		cmds = append(cmds, cmd)
+	}
 
-	if m.focus {
-		if m.suggestionManager.ShouldChangeListPosition(msg) {
-			m.saveCurrentInput()
-		}
+	if m.focus && m.modelState != searching {
+		if m.shouldNavigateHistory(msg) {
+			cmds = append(cmds, m.navigateHistory(msg.(tea.KeyMsg)))
+		} else {
+			if m.suggestionManager.ShouldChangeListPosition(msg) {
+				m.saveCurrentInput()
+			}
 
+			cmds = append(cmds, m.suggestionManager.Update(msg))
+		}
+	} else if _, ok := msg.(tea.WindowSizeMsg); ok {
+		// The layout of the suggestions may depend on the terminal size even while they're hidden
 		cmds = append(cmds, m.suggestionManager.Update(msg))
 	}
 
@@ -79,15 +117,20 @@ func (m Model[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds, scrollToBottom = m.updateExecuting(msg, cmds)
 	case completing:
 		cmds, scrollToBottom = m.updateCompleting(msg, cmds, prevText)
+	case searching:
+		cmds, scrollToBottom = m.updateSearching(msg, cmds)
 	}
 
-	cmd = m.finishUpdate(msg)
-	cmds = append(cmds, cmd)
+	if m.modelState != searching {
+		cmd = m.finishUpdate(msg)
+		cmds = append(cmds, cmd)
+	}
+	m.updateAutosuggestion()
+	m.updatePreview()
 
-	m.renderer.SetInput(m.renderInput())
-	m.renderer.SetBody(m.renderBody())
-	cmd = m.renderer.FinishUpdate()
-	cmds = append(cmds, cmd)
+	cmds = append(cmds, m.updateJobs(msg))
+
+	cmds = append(cmds, m.render())
 
 	if scrollToBottom {
 		m.renderer.GotoBottom(msg)
@@ -96,10 +139,25 @@ func (m Model[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+func (m *Model[T]) render() tea.Cmd {
+	m.renderer.SetInput(m.renderInput())
+	m.updateSuggestionPlacement()
+	if popupSetter, ok := m.renderer.(renderer.PopupSetter); ok {
+		popupSetter.SetPopup(m.renderPopup())
+	}
+	m.renderer.SetBody(m.renderBody())
+	return m.renderer.FinishUpdate()
+}
+
 func (m *Model[T]) updateExecuting(msg tea.Msg, cmds []tea.Cmd) ([]tea.Cmd, bool) {
-	executorManager, cmd := (*m.executionManager).Update(msg)
-	cmds = append(cmds, cmd)
-	m.executionManager = &executorManager
+	switch msg.(type) {
+	case jobMsg, jobFinishedMsg:
+		// Messages for the foreground executor were already unwrapped so these belong to another executor
+	default:
+		executorManager, cmd := (*m.executionManager).Update(msg)
+		cmds = append(cmds, cmd)
+		m.executionManager = &executorManager
+	}
 
 	switch msg.(type) {
 	// Check if the model sent the quit command
@@ -115,7 +173,7 @@ func (m *Model[T]) updateExecuting(msg tea.Msg, cmds []tea.Cmd) ([]tea.Cmd, bool
 			m.textInput.Blur()
 		}
 	}
-	return append(cmds, cmd), true
+	return cmds, true
 }
 
 func (m *Model[T]) updateCompleting(
@@ -130,18 +188,47 @@ func (m *Model[T]) updateCompleting(
 		m.updateWindowSizeMsg(msg)
 	case tea.KeyMsg:
 		scrollToBottom = true
-		switch msg.Type {
-		case tea.KeyEscape:
-			// Escape should only shutdown the program in completer mode, otherwise this could interfere
+		switch {
+		case key.Matches(msg, m.keyMap.Quit):
+			// Quit should only shutdown the program in completer mode, otherwise this could interfere
 			// with the executor model
 			shutdown = true
 			return append(cmds, tea.Quit), scrollToBottom
 
-		case tea.KeyEnter:
-			cmds = m.submit(msg, cmds)
+		case key.Matches(msg, m.keyMap.Submit):
+			if m.isInputComplete() {
+				cmds = m.submit(msg, cmds)
+			} else {
+				cmds = m.insertNewline(cmds)
+			}
+
+		case key.Matches(msg, m.keyMap.HistorySearch):
+			if m.focus && m.history.enabled() {
+				m.startHistorySearch()
+			}
 
-		case tea.KeyBackspace, tea.KeyDelete, tea.KeyRunes, tea.KeySpace, tea.KeyLeft, tea.KeyRight:
-			cmds = m.updateKeypress(msg, cmds, prevRunes)
+		case m.shouldScrollPreview(msg):
+			m.scrollPreview(msg)
+
+		case m.suggestionManager.ShouldChangeListPosition(msg):
+			// Managers can use editing keys such as left and right to navigate the suggestions,
+			// so they shouldn't change the input
+
+		case m.shouldAcceptAutosuggestion(msg):
+			cmds = m.acceptAutosuggestion(msg, cmds, prevRunes)
+
+		default:
+			switch msg.Type {
+			case tea.KeyBackspace, tea.KeyDelete, tea.KeyRunes, tea.KeySpace, tea.KeyLeft, tea.KeyRight:
+				cmds = m.updateKeypress(msg, cmds, prevRunes)
+			default:
+				if !m.keyMap.Suggestion.Matches(msg) &&
+					!key.Matches(msg, m.keyMap.HistoryPrevious, m.keyMap.HistoryNext) &&
+					string(prevRunes) != string(m.textInput.Runes()) {
+					// Some inputs have additional editing keys such as inserting a newline
+					cmds = m.updateKeypress(msg, cmds, prevRunes)
+				}
+			}
 		}
 
 	case errMsg:
@@ -179,7 +266,21 @@ func (m *Model[T]) finishUpdate(msg tea.Msg) tea.Cmd {
 	return m.textInput.OnUpdateFinish(msg, suggestion, isSelected)
 }
 
+func (m *Model[T]) interruptExecutor() tea.Cmd {
+	m.interrupted = true
+	if cmd, ok := m.executionManager.interrupt(); ok {
+		// Wait for the executor to finish on its own
+		return cmd
+	}
+	cmds := []tea.Cmd{m.finalizeExecutor(m.executionManager)}
+	if m.focus {
+		cmds = append(cmds, m.textInput.Focus())
+	}
+	return tea.Batch(cmds...)
+}
+
 func (m *Model[T]) finalizeExecutor(executorManager *executionManager) tea.Cmd {
+	m.interrupted = false
 	m.suggestionManager.UnselectSuggestion()
 	// Store the final executor view in the history
 	// Need to store previous lines in a string instead of a []string in order
@@ -189,7 +290,10 @@ func (m *Model[T]) finalizeExecutor(executorManager *executionManager) tea.Cmd {
 	m.renderer.AddHistory(executorManager.View())
 	m.textInput.OnExecutorFinished()
 	m.updateExecutor(nil)
-	return func() tea.Msg { return ExecutorFinishedMsg(executorManager.inner) }
+	return tea.Sequence(
+		func() tea.Msg { return ExecutorFinishedMsg(executorManager.inner) },
+		m.addResult(executorManager.result(nil)),
+	)
 }
 
 func (m *Model[T]) updateWindowSizeMsg(msg tea.WindowSizeMsg) {
@@ -207,11 +311,12 @@ func (m *Model[T]) saveCurrentInput() {
 		// No suggestion currently suggested, store the last cursor position before selecting
 		// so we can restore it later
 		m.lastTypedCursorPosition = m.textInput.CursorOffset()
+		m.lastTypedCursorIndex = m.textInput.CursorIndex()
 	}
 	// Set the text back to the last thing the user typed in case the current suggestion changed the text length
 	m.textInput.SetValue(string(m.typedRunes))
 	// Make sure to set the cursor AFTER setting the value or it may get overwritten
-	m.textInput.SetCursor(m.lastTypedCursorPosition)
+	m.textInput.SetCursor(m.lastTypedCursorIndex)
 }
 
 func (m *Model[T]) updateExecutor(executor *executionManager) {
@@ -224,23 +329,54 @@ func (m *Model[T]) updateExecutor(executor *executionManager) {
 }
 
 func (m *Model[T]) submit(msg tea.KeyMsg, cmds []tea.Cmd) []tea.Cmd {
-	innerExecutor, err := m.inputHandler.Execute(m.textInput.Value(), m)
+	inputValue := m.textInput.Value()
+	innerExecutor, err := m.inputHandler.Execute(inputValue, m)
 	if innerExecutor == nil {
 		// No executor returned, default to empty model to prevent nil reference errors
 		innerExecutor = executor.NewStringModel("")
 	}
+	historyErr := m.history.add(inputValue)
 	// Reset all text and selection state
 	m.typedRunes = []rune("")
 	m.lastTypedCursorPosition = 0
+	m.lastTypedCursorIndex = 0
 	m.suggestionManager.UnselectSuggestion()
 
 	// Store the user input including the prompt state and the executor result
 	// Pass in the static flag to signal to the text input to exclude interactive elements
 	// such as placeholders and the cursor
 	m.renderer.AddHistory(m.textInput.View(input.Static))
+	if historyErr != nil {
+		// The input still runs, but let the user know it won't be available in future sessions
+		m.renderer.AddHistory(
+			m.suggestionManager.Formatters().ErrorFormatter().Render(fmt.Errorf("failed to save history: %w", historyErr)),
+		)
+	}
 	m.textInput.ResetValue()
 
-	executorManager := newExecutorManager(innerExecutor, m.suggestionManager.Formatters().ErrorText, err)
+	errorFormatter := m.suggestionManager.Formatters().ErrorFormatter()
+	executorManager := newExecutorManager(innerExecutor, inputValue, errorFormatter, err)
+
+	switch model := innerExecutor.(type) {
+	case backgroundModel:
+		if err == nil {
+			executorManager.inner = model.Model
+			cmds = append(cmds, m.startJob(executorManager))
+			m.suggestionManager.ClearSuggestions()
+			return append(cmds, m.suggestionManager.ResetSuggestions())
+		}
+	case foregroundModel:
+		// The job is already running so it doesn't need to be initialized again
+		executorManager = model.manager
+		pending := executorManager.pending
+		executorManager.stopped = false
+		executorManager.pending = nil
+		m.updateExecutor(executorManager)
+		m.renderer.AddHistory(fmt.Sprintf("[%d] %s", executorManager.jobID, executorManager.input))
+		cmds = append(cmds, tea.Sequence(executorManager.resume(), replayMsgs(pending), func() tea.Msg { return m.size }))
+		m.suggestionManager.ClearSuggestions()
+		return append(cmds, m.suggestionManager.ResetSuggestions())
+	}
 
 	// Performance optimization: if this is a string model, we don't need to go through the whole update cycle
 	// Just call the view method once and finalize the result
@@ -258,7 +394,32 @@ func (m *Model[T]) submit(msg tea.KeyMsg, cmds []tea.Cmd) []tea.Cmd {
 	return append(cmds, m.suggestionManager.ResetSuggestions())
 }
 
+func (m Model[T]) isInputComplete() bool {
+	if handler, ok := m.inputHandler.(MultilineInputHandler); ok {
+		return handler.IsInputComplete(m.textInput.Value())
+	}
+	return true
+}
+
+func (m *Model[T]) insertNewline(cmds []tea.Cmd) []tea.Cmd {
+	runes := m.textInput.Runes()
+	cursor := m.textInput.CursorIndex()
+	m.suggestionManager.UnselectSuggestion()
+	m.textInput.SetValue(string(runes[:cursor]) + "\n" + string(runes[cursor:]))
+	m.textInput.SetCursor(cursor + 1)
+	m.history.reset()
+
+	m.lastTypedCursorPosition = m.textInput.CursorOffset()
+	m.lastTypedCursorIndex = m.textInput.CursorIndex()
+	m.typedRunes = m.textInput.Runes()
+	return append(cmds, m.suggestionManager.UpdateSuggestions())
+}
+
 func (m *Model[T]) updateKeypress(msg tea.KeyMsg, cmds []tea.Cmd, prevRunes []rune) []tea.Cmd {
+	if string(prevRunes) != string(m.textInput.Runes()) {
+		// The user edited the recalled entry so the next recall should start from the newest entry again
+		m.history.reset()
+	}
 	cmds = m.updatePosition(msg, cmds)
 	if m.textInput.ShouldClearSuggestions(prevRunes, msg) {
 		m.suggestionManager.ClearSuggestions()
@@ -273,8 +434,65 @@ func (m *Model[T]) updateKeypress(msg tea.KeyMsg, cmds []tea.Cmd, prevRunes []ru
 
 func (m *Model[T]) updatePosition(msg tea.KeyMsg, cmds []tea.Cmd) []tea.Cmd {
 	m.lastTypedCursorPosition = m.textInput.CursorOffset()
+	m.lastTypedCursorIndex = m.textInput.CursorIndex()
 	m.typedRunes = m.textInput.Runes()
 	cmds = append(cmds, m.suggestionManager.UpdateSuggestions())
 
 	return cmds
 }
+
+func (m Model[T]) shouldNavigateHistory(msg tea.Msg) bool {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || m.modelState != completing || !m.history.enabled() {
+		return false
+	}
+	// Keep cycling through the history once the user started navigating it,
+	// even if the recalled entry produced new suggestions, until a suggestion is selected
+	if m.history.navigating && !m.suggestionManager.IsSuggestionSelected() {
+		return key.Matches(keyMsg, m.keyMap.HistoryPrevious, m.keyMap.HistoryNext)
+	}
+	// Otherwise, only start navigating if there's no suggestion list that the keys would move through
+	return key.Matches(keyMsg, m.keyMap.HistoryPrevious) && len(m.suggestionManager.Suggestions()) == 0
+}
+
+func (m *Model[T]) navigateHistory(msg tea.KeyMsg) tea.Cmd {
+	var entry string
+	var ok bool
+	if key.Matches(msg, m.keyMap.HistoryPrevious) {
+		entry, ok = m.history.previous(m.textInput.Value())
+	} else {
+		entry, ok = m.history.next()
+	}
+	if !ok {
+		return nil
+	}
+
+	m.suggestionManager.UnselectSuggestion()
+	m.textInput.SetValue(entry)
+	m.textInput.SetCursor(len([]rune(entry)))
+	// Treat the recalled entry as if the user typed it
+	m.typedRunes = m.textInput.Runes()
+	m.lastTypedCursorPosition = m.textInput.CursorOffset()
+	m.lastTypedCursorIndex = m.textInput.CursorIndex()
+
+	return m.suggestionManager.UpdateSuggestions()
+}
+
+func (m Model[T]) complete(ctx context.Context) ([]suggestion.Suggestion[T], error) {
+	if m.completionDebounce > 0 {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(m.completionDebounce):
+		}
+	}
+	if source, ok := m.textInput.(input.SuggestionSource[T]); ok {
+		if suggestions, ok := source.ValueSuggestions(); ok {
+			return suggestions, nil
+		}
+	}
+	if completer, ok := m.inputHandler.(ContextCompleter[T]); ok {
+		return completer.CompleteContext(ctx, m)
+	}
+	return m.inputHandler.Complete(m)
+}